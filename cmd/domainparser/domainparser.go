@@ -8,45 +8,37 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
-	"sort"
 	"strings"
 )
 
-// tld contains single tld info
-type tld map[string]*tld
-
-func (t *tld) Source() string {
-	// Report nil if nothing is present
-	if len(*t) == 0 {
-		return "nil"
-	}
-
-	// Create set of keys (for sorting)
-	keys, i := make([]string, len(*t)), 0
-	for key := range *t {
-		keys[i] = key
-		i++
+func checkError(err error) {
+	if err != nil {
+		log.Fatal(err.Error())
+		os.Exit(1)
 	}
-	sort.Strings(keys)
+}
 
-	// Create source code based on sorted keys
-	str := "&tld{\n"
-	for _, key := range keys {
-		str += `"` + key + `": `
-		str += (*t)[key].Source()
-		str += ",\n"
+// readList reads the list from http(s) url or local file.
+func readList(source string) ([]byte, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		return ioutil.ReadFile(source)
 	}
-	return str + "}"
-}
 
-func checkError(err error) {
+	// Do the http request
+	resp, err := http.Get(source)
 	if err != nil {
-		log.Fatal(err.Error())
-		os.Exit(1)
+		return nil, err
 	}
+	defer resp.Body.Close()
+
+	// Read the listing from request body
+	return ioutil.ReadAll(resp.Body)
 }
 
 func main() {
+	listSource := flag.String("source", "https://publicsuffix.org/list/public_suffix_list.dat", "url or local path of the public suffix list")
+	flag.Parse()
+
 	// Get path as argument
 	args := flag.Args()
 	if len(args) == 0 {
@@ -54,46 +46,21 @@ func main() {
 		args = []string{"."}
 	}
 
-	// Do the http request
-	resp, err := http.Get("https://publicsuffix.org/list/public_suffix_list.dat")
+	b, err := readList(*listSource)
 	checkError(err)
 
-	// Read the listing from request body
-	b, err := ioutil.ReadAll(resp.Body)
-	checkError(err)
-
-	// Generate basic tree
-	tlds := &tld{}
-
-	// Parse text as separate lines
-	lines := strings.Split(string(b), "\n")
-	for _, line := range lines {
-		if line != "" && (len(line) < 2 || line[:2] != "//") {
-			currentTLD := tlds
-			parts := strings.Split(line, ".")
-			for p := len(parts) - 1; p >= 0; p-- {
-				part := parts[p]
-				if nextTLD, exists := (*currentTLD)[part]; !exists {
-					nextTLD = &tld{}
-					(*currentTLD)[part] = nextTLD
-					currentTLD = nextTLD
-				} else {
-					currentTLD = nextTLD
-				}
-			}
-		}
+	// The list is embedded verbatim in a raw string, so it must not contain backquotes.
+	// Keeping comments & section markers lets the package report rule provenance.
+	list := strings.Replace(string(b), "\r", "", -1)
+	if strings.Contains(list, "`") || !strings.Contains(list, "===BEGIN PRIVATE DOMAINS===") {
+		log.Fatal("unexpected format of the public suffix list")
 	}
 
 	// Create tlds file
-	source := `// Code generated by github.com/bobesa/go-domain-util, DO NOT EDIT.
-	
-	package domainutil
-
-	// tld contains single tld info
-	type tld map[string]*tld
-
-	// tlds holds all informations about correct tlds
-	var tlds = ` + tlds.Source()
+	source := "// Code generated by github.com/bobesa/go-domain-util, DO NOT EDIT.\n\n" +
+		"package domainutil\n\n" +
+		"// publicSuffixList holds the public suffix list from publicsuffix.org\n" +
+		"const publicSuffixList = `" + list + "`\n"
 
 	// Run gofmt to format the code
 	cmd := exec.Command("gofmt")
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"container/heap"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bobesa/go-domain-util/domainutil"
+)
+
+// countKeys maps aggregation modes to functions extracting the counted key from parsed line
+var countKeys = map[string]func(parts domainutil.Parts) string{
+	"domain": func(parts domainutil.Parts) string { return parts.Domain },
+	"suffix": func(parts domainutil.Parts) string { return parts.Suffix },
+	"tld": func(parts domainutil.Parts) string {
+		return parts.Suffix[strings.LastIndex(parts.Suffix, ".")+1:]
+	},
+}
+
+// countOptions holds options of the aggregation
+type countOptions struct {
+	Mode     string // Key to count by (domain, suffix or tld)
+	Top      int    // Number of keys to print, zero prints all
+	Min      int    // Minimal count of printed keys
+	SortBy   string // Order of printed keys (count or name)
+	Capacity int    // Maximal number of tracked keys, zero counts exactly
+}
+
+// counted is a single counted key
+type counted struct {
+	key   string
+	count int // Count, upper bound of the real count in approximate mode
+	error int // Maximal overestimation of the count
+	index int // Position in the heap
+}
+
+// countedHeap is a min-heap of counted keys ordered by count
+type countedHeap []*counted
+
+func (h countedHeap) Len() int           { return len(h) }
+func (h countedHeap) Less(i, j int) bool { return h[i].count < h[j].count }
+func (h countedHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index, h[j].index = i, j
+}
+func (h *countedHeap) Push(x interface{}) {
+	item := x.(*counted)
+	item.index = len(*h)
+	*h = append(*h, item)
+}
+func (h *countedHeap) Pop() interface{} {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
+
+// counter counts keys exactly or, if capacity is set, approximately with the Space-Saving algorithm.
+// In approximate mode at most capacity keys are tracked. When a new key arrives, it replaces
+// the least counted key and inherits its count, so counts are never underestimated.
+type counter struct {
+	keys     map[string]*counted
+	heap     countedHeap
+	capacity int
+}
+
+// newCounter creates counter tracking at most capacity keys, zero capacity counts exactly.
+func newCounter(capacity int) *counter {
+	return &counter{keys: map[string]*counted{}, capacity: capacity}
+}
+
+// add counts single occurrence of key.
+func (c *counter) add(key string) {
+	if item, found := c.keys[key]; found {
+		item.count++
+		if c.capacity > 0 {
+			heap.Fix(&c.heap, item.index)
+		}
+		return
+	}
+
+	switch {
+	case c.capacity == 0:
+		c.keys[key] = &counted{key: key, count: 1}
+	case len(c.keys) < c.capacity:
+		item := &counted{key: key, count: 1}
+		c.keys[key] = item
+		heap.Push(&c.heap, item)
+	default:
+		// Replace the least counted key
+		item := c.heap[0]
+		delete(c.keys, item.key)
+		item.key, item.error = key, item.count
+		item.count++
+		c.keys[key] = item
+		heap.Fix(&c.heap, 0)
+	}
+}
+
+// result returns counted keys passing the options in requested order.
+func (c *counter) result(options countOptions) []*counted {
+	items := make([]*counted, 0, len(c.keys))
+	for _, item := range c.keys {
+		if item.count >= options.Min {
+			items = append(items, item)
+		}
+	}
+
+	// Top keys are always the most counted ones, the order is applied on them
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].count != items[j].count {
+			return items[i].count > items[j].count
+		}
+		return items[i].key < items[j].key
+	})
+	if options.Top > 0 && len(items) > options.Top {
+		items = items[:options.Top]
+	}
+	if options.SortBy == "name" {
+		sort.Slice(items, func(i, j int) bool { return items[i].key < items[j].key })
+	}
+	return items
+}
+
+// countColumns returns names of the columns printed by the aggregation.
+func countColumns(options countOptions) []string {
+	if options.Capacity > 0 {
+		return []string{"count", options.Mode, "error"}
+	}
+	return []string{"count", options.Mode}
+}
+
+// checkCountOptions validates options of the aggregation.
+func checkCountOptions(options countOptions) error {
+	if _, found := countKeys[options.Mode]; !found {
+		return fmt.Errorf("unknown aggregation %q", options.Mode)
+	}
+	if options.SortBy != "count" && options.SortBy != "name" {
+		return fmt.Errorf("unknown sort order %q", options.SortBy)
+	}
+	if options.Top < 0 || options.Min < 0 || options.Capacity < 0 {
+		return fmt.Errorf("negative limits are not allowed")
+	}
+	return nil
+}
+
+// writeCounts counts parsed lines by the key of the aggregation and writes the result.
+func writeCounts(files []string, out recordWriter, options countOptions) error {
+	key, counts := countKeys[options.Mode], newCounter(options.Capacity)
+	err := eachLine(files, func(name string, number int, line string) {
+		parts, err := domainutil.Parse(line)
+		if err != nil {
+			log.Printf("%s:%d: %s: %q", name, number, strings.TrimPrefix(err.Error(), "domainutil: "), line)
+			return
+		}
+		counts.add(key(parts))
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, item := range counts.result(options) {
+		record := []string{strconv.Itoa(item.count), item.key}
+		if options.Capacity > 0 {
+			record = append(record, strconv.Itoa(item.error))
+		}
+		if err := out.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
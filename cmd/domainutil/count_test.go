@@ -0,0 +1,68 @@
+package main
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestCounterResult tests counter.result() method with exact & approximate counting
+func TestCounterResult(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		keys     string
+		options  countOptions
+		expected []string
+	}{
+		{"exact", "a b a c b a", countOptions{}, []string{"a:3:0", "b:2:0", "c:1:0"}},
+		{"ties by name", "c b a", countOptions{}, []string{"a:1:0", "b:1:0", "c:1:0"}},
+		{"top", "a b a c b a", countOptions{Top: 2}, []string{"a:3:0", "b:2:0"}},
+		{"min", "a b a c b a", countOptions{Min: 2}, []string{"a:3:0", "b:2:0"}},
+		{"sort by name", "c c c a b b", countOptions{SortBy: "name"}, []string{"a:1:0", "b:2:0", "c:3:0"}},
+		{"top sorted by name", "c c c a b b", countOptions{Top: 2, SortBy: "name"}, []string{"b:2:0", "c:3:0"}},
+		{"top before min", "a a b b c", countOptions{Top: 1, Min: 2}, []string{"a:2:0"}},
+		{"approx within capacity", "a b a", countOptions{Capacity: 2}, []string{"a:2:0", "b:1:0"}},
+		{"approx replaces least counted", "a a b c", countOptions{Capacity: 2}, []string{"a:2:0", "c:2:1"}},
+		{"approx replaced key keeps counting", "a a a b c c", countOptions{Capacity: 2}, []string{"a:3:0", "c:3:1"}},
+		{"approx replaced twice", "a a a b c d", countOptions{Capacity: 2}, []string{"a:3:0", "d:3:2"}},
+		{"approx min counts overestimate", "a a b c", countOptions{Capacity: 2, Min: 2, SortBy: "name"}, []string{"a:2:0", "c:2:1"}},
+	} {
+		counts := newCounter(test.options.Capacity)
+		for _, key := range strings.Fields(test.keys) {
+			counts.add(key)
+		}
+
+		var result []string
+		for _, item := range counts.result(test.options) {
+			result = append(result, item.key+":"+strconv.Itoa(item.count)+":"+strconv.Itoa(item.error))
+		}
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("Counter (%s) returned %v for result(), but %v was expected", test.name, result, test.expected)
+		}
+	}
+}
+
+// TestCounterBounds tests that approximate counts never underestimate and stay within their error
+func TestCounterBounds(t *testing.T) {
+	exact, approx := newCounter(0), newCounter(8)
+	for i := 0; i < 10000; i++ {
+		key := strconv.Itoa(i % 7 * (i % 13))
+		exact.add(key)
+		approx.add(key)
+	}
+
+	counts := map[string]int{}
+	for _, item := range exact.result(countOptions{}) {
+		counts[item.key] = item.count
+	}
+	result := approx.result(countOptions{})
+	if len(result) != 8 {
+		t.Errorf("Counter returned %d keys for result(), but 8 were expected", len(result))
+	}
+	for _, item := range result {
+		if count := counts[item.key]; item.count < count || item.count-item.error > count {
+			t.Errorf("Key (%q) returned count %d with error %d, but real count %d is out of bounds", item.key, item.count, item.error, count)
+		}
+	}
+}
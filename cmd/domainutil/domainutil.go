@@ -0,0 +1,260 @@
+package main // import "github.com/bobesa/go-domain-util/cmd/domainutil"
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/bobesa/go-domain-util/domainutil"
+)
+
+// columns maps column names to functions extracting them from parsed line
+var columns = map[string]func(input string, parts domainutil.Parts) string{
+	"input":     func(input string, parts domainutil.Parts) string { return input },
+	"protocol":  func(input string, parts domainutil.Parts) string { return parts.Protocol },
+	"username":  func(input string, parts domainutil.Parts) string { return parts.Username },
+	"password":  func(input string, parts domainutil.Parts) string { return parts.Password },
+	"host":      func(input string, parts domainutil.Parts) string { return parts.Host },
+	"subdomain": func(input string, parts domainutil.Parts) string { return parts.Subdomain },
+	"sld":       func(input string, parts domainutil.Parts) string { return parts.SLD },
+	"suffix":    func(input string, parts domainutil.Parts) string { return parts.Suffix },
+	"domain":    func(input string, parts domainutil.Parts) string { return parts.Domain },
+}
+
+// numericColumns holds columns written as numbers to JSON
+var numericColumns = map[string]bool{
+	"count": true,
+	"error": true,
+}
+
+// recordWriter writes records of the selected columns
+type recordWriter interface {
+	Write(record []string) error
+	Flush() error
+}
+
+// tsvWriter writes records as tab separated values
+type tsvWriter struct {
+	*bufio.Writer
+}
+
+func (w tsvWriter) Write(record []string) error {
+	_, err := w.WriteString(strings.Join(record, "\t") + "\n")
+	return err
+}
+
+// csvWriter writes records as comma separated values
+type csvWriter struct {
+	*csv.Writer
+}
+
+func (w csvWriter) Flush() error {
+	w.Writer.Flush()
+	return w.Error()
+}
+
+// jsonWriter writes records as JSON objects, one per line
+type jsonWriter struct {
+	*bufio.Writer
+	names []string
+}
+
+func (w jsonWriter) Write(record []string) error {
+	w.WriteByte('{')
+	for i, value := range record {
+		if i > 0 {
+			w.WriteByte(',')
+		}
+		name, _ := json.Marshal(w.names[i])
+		encoded, _ := json.Marshal(value)
+		if numericColumns[w.names[i]] {
+			encoded = []byte(value)
+		}
+		w.Writer.Write(name)
+		w.WriteByte(':')
+		w.Writer.Write(encoded)
+	}
+	_, err := w.WriteString("}\n")
+	return err
+}
+
+// newRecordWriter creates writer of provided format.
+func newRecordWriter(out io.Writer, format string, names []string) (recordWriter, error) {
+	switch format {
+	case "tsv":
+		return tsvWriter{bufio.NewWriter(out)}, nil
+	case "csv":
+		return csvWriter{csv.NewWriter(out)}, nil
+	case "jsonl":
+		return jsonWriter{bufio.NewWriter(out), names}, nil
+	}
+	return nil, fmt.Errorf("unknown format %q", format)
+}
+
+// eachLine calls fn for every non-empty line of the files (or stdin if no files are given).
+func eachLine(files []string, fn func(name string, number int, line string)) error {
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	for _, name := range files {
+		if err := eachFileLine(name, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// eachFileLine calls fn for every non-empty line of the file, "-" stands for stdin.
+func eachFileLine(name string, fn func(name string, number int, line string)) error {
+	var in io.Reader = os.Stdin
+	if name == "-" {
+		name = "stdin"
+	} else {
+		file, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in = file
+	}
+
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for number := 1; scanner.Scan(); number++ {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			fn(name, number, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("%s: %v", name, err)
+	}
+	return nil
+}
+
+// writeParts parses every line and writes its selected columns.
+func writeParts(files []string, out recordWriter, names []string) error {
+	var writeErr error
+	err := eachLine(files, func(name string, number int, line string) {
+		parts, err := domainutil.Parse(line)
+		if err != nil {
+			log.Printf("%s:%d: %s: %q", name, number, strings.TrimPrefix(err.Error(), "domainutil: "), line)
+			return
+		}
+
+		record := make([]string, len(names))
+		for i, column := range names {
+			record[i] = columns[column](line, parts)
+		}
+		if writeErr == nil {
+			writeErr = out.Write(record)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return writeErr
+}
+
+// writeExplanations writes how every line is split by the suffix list, as text or JSON lines.
+func writeExplanations(files []string, out io.Writer, asJSON bool) error {
+	w := bufio.NewWriter(out)
+	encoder := json.NewEncoder(w)
+	err := eachLine(files, func(name string, number int, line string) {
+		explanation := domainutil.Explain(line)
+		if asJSON {
+			encoder.Encode(struct {
+				Input string
+				domainutil.Explanation
+			}{line, explanation})
+			return
+		}
+		fmt.Fprintf(w, "input:  %s\n%s\n", line, explanation)
+	})
+	if flushErr := w.Flush(); err == nil {
+		err = flushErr
+	}
+	return err
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("domainutil: ")
+
+	format := flag.String("format", "tsv", "output format: tsv, csv or jsonl (text or json with -tree, text or jsonl with -explain)")
+	columnList := flag.String("columns", "input,protocol,username,subdomain,sld,suffix,domain", "comma separated columns to print: input, protocol, username, password, host, subdomain, sld, suffix, domain")
+	header := flag.Bool("header", false, "print column names before the records (tsv and csv only)")
+	tree := flag.Bool("tree", false, "print hosts as tree of suffixes, registrable domains and subdomains with counts")
+	explain := flag.Bool("explain", false, "print which suffix list rule matched every line and why (text, or jsonl)")
+	var counting countOptions
+	flag.StringVar(&counting.Mode, "count", "", "count lines by domain, suffix or tld instead of printing them")
+	flag.IntVar(&counting.Top, "top", 0, "print only the top N counted keys, 0 prints all (with -count)")
+	flag.IntVar(&counting.Min, "min", 1, "print only keys counted at least N times (with -count)")
+	flag.StringVar(&counting.SortBy, "sort", "count", "order of counted keys: count or name (with -count)")
+	flag.IntVar(&counting.Capacity, "approx", 0, "count approximately, tracking at most N keys to bound memory (with -count)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [file ...]\n\nParses urls or hosts, one per line, from the files or stdin.\nLines that cannot be parsed are reported on stderr.\n\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// Check columns
+	names := strings.Split(*columnList, ",")
+	for i, name := range names {
+		names[i] = strings.ToLower(strings.TrimSpace(name))
+		if _, found := columns[names[i]]; !found {
+			log.Fatalf("unknown column %q", name)
+		}
+	}
+	if counting.Mode != "" {
+		if err := checkCountOptions(counting); err != nil {
+			log.Fatal(err)
+		}
+		names = countColumns(counting)
+	}
+
+	if *explain {
+		if *format != "tsv" && *format != "text" && *format != "jsonl" {
+			log.Fatalf("unknown explain format %q", *format)
+		}
+		if err := writeExplanations(flag.Args(), os.Stdout, *format == "jsonl"); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if *tree {
+		if *format != "tsv" && *format != "text" && *format != "json" {
+			log.Fatalf("unknown tree format %q", *format)
+		}
+		if err := writeTree(flag.Args(), os.Stdout, *format == "json"); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	out, err := newRecordWriter(os.Stdout, *format, names)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *header && *format != "jsonl" {
+		out.Write(names)
+	}
+
+	if counting.Mode != "" {
+		err = writeCounts(flag.Args(), out, counting)
+	} else {
+		err = writeParts(flag.Args(), out, names)
+	}
+	if flushErr := out.Flush(); err == nil {
+		err = flushErr
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
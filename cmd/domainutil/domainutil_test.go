@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestRecordWriter tests record writers of all formats
+func TestRecordWriter(t *testing.T) {
+	names := []string{"count", "domain", "error"}
+	records := [][]string{{"3", "example.com", "1"}, {"12", `quo"te,comma`, "0"}}
+	for format, expected := range map[string]string{
+		"tsv":   "3\texample.com\t1\n12\tquo\"te,comma\t0\n",
+		"csv":   "3,example.com,1\n12,\"quo\"\"te,comma\",0\n",
+		"jsonl": `{"count":3,"domain":"example.com","error":1}` + "\n" + `{"count":12,"domain":"quo\"te,comma","error":0}` + "\n",
+	} {
+		var out bytes.Buffer
+		w, err := newRecordWriter(&out, format, names)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, record := range records {
+			if err := w.Write(record); err != nil {
+				t.Errorf("Format (%q) returned error %v for Write()", format, err)
+			}
+		}
+		if err := w.Flush(); err != nil {
+			t.Errorf("Format (%q) returned error %v for Flush()", format, err)
+		}
+		if out.String() != expected {
+			t.Errorf("Format (%q) returned %q, but %q was expected", format, out.String(), expected)
+		}
+	}
+
+	if _, err := newRecordWriter(&bytes.Buffer{}, "xml", names); err == nil {
+		t.Errorf("newRecordWriter() accepted unknown format")
+	}
+}
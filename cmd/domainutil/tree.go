@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bobesa/go-domain-util/domainutil"
+)
+
+// treeNode is a single node of the host hierarchy (suffix, registrable domain or subdomain)
+type treeNode struct {
+	Name     string      `json:"name"`
+	Count    int         `json:"count"`
+	Children []*treeNode `json:"children,omitempty"`
+	index    map[string]*treeNode
+}
+
+// child returns child node of provided name, creating it if needed.
+func (n *treeNode) child(name string) *treeNode {
+	if n.index == nil {
+		n.index = map[string]*treeNode{}
+	}
+	child, found := n.index[name]
+	if !found {
+		child = &treeNode{Name: name}
+		n.index[name] = child
+		n.Children = append(n.Children, child)
+	}
+	return child
+}
+
+// add counts host split into labels by domainutil.SplitDomain under the node.
+// The hierarchy is suffix, registrable domain and then subdomain labels from right to left.
+func (n *treeNode) add(labels []string) {
+	suffix := labels[len(labels)-1]
+	name := labels[len(labels)-2] + "." + suffix
+
+	node := n.child(suffix)
+	node.Count++
+	node = node.child(name)
+	node.Count++
+	for i := len(labels) - 3; i >= 0; i-- {
+		name = labels[i] + "." + name
+		node = node.child(name)
+		node.Count++
+	}
+}
+
+// sort orders children of the node and all its descendants by count and name.
+func (n *treeNode) sort() {
+	sort.Slice(n.Children, func(i, j int) bool {
+		if n.Children[i].Count != n.Children[j].Count {
+			return n.Children[i].Count > n.Children[j].Count
+		}
+		return n.Children[i].Name < n.Children[j].Name
+	})
+	for _, child := range n.Children {
+		child.sort()
+	}
+}
+
+// writeText writes children of the node as indented text.
+func (n *treeNode) writeText(out *bufio.Writer, depth int) {
+	for _, child := range n.Children {
+		out.WriteString(strings.Repeat("  ", depth) + child.Name + " (" + strconv.Itoa(child.Count) + ")\n")
+		child.writeText(out, depth+1)
+	}
+}
+
+// writeTree reads hosts and writes them as hierarchy of suffixes, registrable domains & subdomains.
+func writeTree(files []string, out io.Writer, asJSON bool) error {
+	root := &treeNode{}
+	err := eachLine(files, func(name string, number int, line string) {
+		labels := domainutil.SplitDomain(line)
+		if len(labels) < 2 {
+			log.Printf("%s:%d: no registrable domain found: %q", name, number, line)
+			return
+		}
+		root.add(labels)
+	})
+	if err != nil {
+		return err
+	}
+	root.sort()
+
+	if asJSON {
+		if root.Children == nil {
+			root.Children = []*treeNode{}
+		}
+		return json.NewEncoder(out).Encode(root.Children)
+	}
+	buffered := bufio.NewWriter(out)
+	root.writeText(buffered, 0)
+	return buffered.Flush()
+}
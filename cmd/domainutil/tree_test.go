@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestWriteTree tests writeTree() function in text & JSON formats
+func TestWriteTree(t *testing.T) {
+	dir, err := ioutil.TempDir("", "domainutil")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	for _, test := range []struct {
+		name     string
+		input    string
+		asJSON   bool
+		expected string
+	}{
+		{
+			"text",
+			"www.google.com\nmail.google.com\n\ngoogle.com\nbbc.co.uk\nhttps://news.bbc.co.uk/sport\nlocalhost\n",
+			false,
+			"com (3)\n  google.com (3)\n    mail.google.com (1)\n    www.google.com (1)\nco.uk (2)\n  bbc.co.uk (2)\n    news.bbc.co.uk (1)\n",
+		},
+		{
+			"deep subdomains",
+			"a.b.example.org\nc.b.example.org\nb.example.org\n",
+			false,
+			"org (3)\n  example.org (3)\n    b.example.org (3)\n      a.b.example.org (1)\n      c.b.example.org (1)\n",
+		},
+		{
+			"json",
+			"www.example.org\n",
+			true,
+			`[{"name":"org","count":1,"children":[{"name":"example.org","count":1,"children":[{"name":"www.example.org","count":1}]}]}]` + "\n",
+		},
+		{"empty text", "localhost\n", false, ""},
+		{"empty json", "", true, "[]\n"},
+	} {
+		file := filepath.Join(dir, "hosts.txt")
+		if err := ioutil.WriteFile(file, []byte(test.input), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		var out bytes.Buffer
+		if err := writeTree([]string{file}, &out, test.asJSON); err != nil {
+			t.Errorf("Tree (%s) returned error %v for writeTree()", test.name, err)
+		}
+		if out.String() != test.expected {
+			t.Errorf("Tree (%s) returned %q for writeTree(), but %q was expected", test.name, out.String(), test.expected)
+		}
+	}
+}
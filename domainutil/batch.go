@@ -0,0 +1,164 @@
+package domainutil
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// BatchOptions configures parallel parsing of ParseStream and ParseLines.
+type BatchOptions struct {
+	Workers   int  // Number of parsing goroutines, runtime.GOMAXPROCS(0) if not set
+	Unordered bool // Deliver results as soon as they are parsed instead of in the input order
+}
+
+// Result is a single record parsed by ParseStream or ParseLines.
+type Result struct {
+	Index int    // Position of the record in the input, starting with 0
+	Input string // Parsed url
+	Parts Parts  // Parts of the url as returned by Parse
+	Err   error  // Error returned by Parse
+}
+
+// batchJob is a single record waiting for a worker
+type batchJob struct {
+	index int
+	input string
+	done  chan Result // Slot for the result in ordered mode
+}
+
+// ParseStream parses urls received from in by a pool of workers using Parse.
+//
+// The returned channel is closed once in is closed and all the results are delivered
+// or when ctx is cancelled. In ordered mode at most twice the number of workers records
+// are parsed ahead of the consumer, so memory stays bounded even if a record is slow.
+func ParseStream(ctx context.Context, in <-chan string, options BatchOptions) <-chan Result {
+	return std().ParseStream(ctx, in, options)
+}
+
+// ParseLines parses lines of r by a pool of workers, see ParseStream.
+// Index of each result is its line number minus one, surrounding white space of lines is trimmed.
+//
+// When reading is over, the error channel receives the read error, ctx.Err() if ctx was cancelled, or nil.
+func ParseLines(ctx context.Context, r io.Reader, options BatchOptions) (<-chan Result, <-chan error) {
+	return std().ParseLines(ctx, r, options)
+}
+
+// ParseStream parses urls received from in by a pool of workers using the Parse method, see the ParseStream function.
+func (p *Parser) ParseStream(ctx context.Context, in <-chan string, options BatchOptions) <-chan Result {
+	workers := options.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	out, jobs := make(chan Result, workers), make(chan batchJob, workers)
+
+	// Ordered mode keeps result slots in input order
+	var pending chan chan Result
+	if !options.Unordered {
+		pending = make(chan chan Result, 2*workers)
+	}
+
+	// Dispatch records to workers
+	go func() {
+		defer close(jobs)
+		if pending != nil {
+			defer close(pending)
+		}
+		for index := 0; ; index++ {
+			job := batchJob{index: index}
+			select {
+			case input, ok := <-in:
+				if !ok {
+					return
+				}
+				job.input = input
+			case <-ctx.Done():
+				return
+			}
+
+			if pending != nil {
+				job.done = make(chan Result, 1)
+				select {
+				case pending <- job.done:
+				case <-ctx.Done():
+					return
+				}
+			}
+			select {
+			case jobs <- job:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// Parse records
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				parts, err := p.Parse(job.input)
+				result := Result{Index: job.index, Input: job.input, Parts: parts, Err: err}
+				if job.done != nil {
+					job.done <- result
+					continue
+				}
+				select {
+				case out <- result:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	if pending == nil {
+		go func() {
+			wg.Wait()
+			close(out)
+		}()
+		return out
+	}
+
+	// Collect results in input order
+	go func() {
+		defer close(out)
+		for done := range pending {
+			select {
+			case result := <-done:
+				select {
+				case out <- result:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// ParseLines parses lines of r by a pool of workers, see the ParseLines function.
+func (p *Parser) ParseLines(ctx context.Context, r io.Reader, options BatchOptions) (<-chan Result, <-chan error) {
+	lines, errc := make(chan string), make(chan error, 1)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			select {
+			case lines <- strings.TrimSpace(scanner.Text()):
+			case <-ctx.Done():
+				errc <- ctx.Err()
+				return
+			}
+		}
+		errc <- scanner.Err()
+	}()
+	return p.ParseStream(ctx, lines, options), errc
+}
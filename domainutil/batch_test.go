@@ -0,0 +1,94 @@
+package domainutil
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func ExampleParseLines() {
+	results, _ := ParseLines(context.Background(), strings.NewReader("keep.google.com\nnonexist.***\nhttps://google.co.uk/\n"), BatchOptions{Workers: 4})
+	for result := range results {
+		fmt.Println(result.Index, result.Parts.Domain, result.Err)
+	}
+	// Output: 0 google.com <nil>
+	// 1  domainutil: no registrable domain found
+	// 2 google.co.uk <nil>
+}
+
+// batchInput returns n lines of hosts, every tenth without registrable domain
+func batchInput(n int) string {
+	lines := make([]string, n)
+	for i := range lines {
+		if i%10 == 9 {
+			lines[i] = "host" + strconv.Itoa(i) + ".***"
+		} else {
+			lines[i] = "host" + strconv.Itoa(i) + ".google.com"
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// TestParseLines tests ParseLines() function in both ordered & unordered mode
+func TestParseLines(t *testing.T) {
+	for _, unordered := range []bool{false, true} {
+		results, errc := ParseLines(context.Background(), strings.NewReader(batchInput(1000)), BatchOptions{Workers: 8, Unordered: unordered})
+
+		seen := make([]bool, 1000)
+		next := 0
+		for result := range results {
+			if !unordered && result.Index != next {
+				t.Fatalf("ParseLines() returned record %d, but %d was expected", result.Index, next)
+			}
+			next++
+			seen[result.Index] = true
+
+			expected := "host" + strconv.Itoa(result.Index)
+			if result.Index%10 == 9 {
+				if result.Err != ErrNoDomain || result.Input != expected+".***" {
+					t.Errorf("ParseLines() returned %+v for record %d, but ErrNoDomain was expected", result, result.Index)
+				}
+			} else if result.Err != nil || result.Parts.Subdomain != expected || result.Input != expected+".google.com" {
+				t.Errorf("ParseLines() returned %+v for record %d, but subdomain %q was expected", result, result.Index, expected)
+			}
+		}
+		if err := <-errc; err != nil {
+			t.Errorf("ParseLines() returned error %v", err)
+		}
+		for index, found := range seen {
+			if !found {
+				t.Errorf("ParseLines() did not return record %d (unordered: %v)", index, unordered)
+			}
+		}
+	}
+}
+
+// TestParseStreamCancel tests that ParseStream() stops when context is cancelled
+func TestParseStreamCancel(t *testing.T) {
+	for _, unordered := range []bool{false, true} {
+		ctx, cancel := context.WithCancel(context.Background())
+		in := make(chan string)
+		results := ParseStream(ctx, in, BatchOptions{Workers: 2, Unordered: unordered})
+
+		in <- "keep.google.com"
+		if result := <-results; result.Parts.Domain != "google.com" {
+			t.Errorf("ParseStream() returned %+v, but google.com was expected", result)
+		}
+		cancel()
+		for range results {
+		}
+	}
+}
+
+// BenchmarkParseLines benchmarks ParseLines() function
+func BenchmarkParseLines(b *testing.B) {
+	input := batchInput(10000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		results, _ := ParseLines(context.Background(), strings.NewReader(input), BatchOptions{})
+		for range results {
+		}
+	}
+}
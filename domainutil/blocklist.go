@@ -0,0 +1,237 @@
+package domainutil
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// BlocklistFormat is a format of a host blocklist
+type BlocklistFormat int
+
+const (
+	// HostsFormat is the /etc/hosts format (0.0.0.0 ads.example.com), every host is matched exactly
+	HostsFormat BlocklistFormat = iota
+	// DnsmasqFormat is the dnsmasq configuration format (address=/example.com/), every domain is matched with its subdomains
+	DnsmasqFormat
+	// AdblockFormat is the Adblock Plus filter format restricted to host rules (||example.com^ and @@||example.com^)
+	AdblockFormat
+)
+
+// ignoredHostsEntries holds names mapped in hosts files to loopback addresses, which are not blocked hosts
+var ignoredHostsEntries = map[string]bool{
+	"0.0.0.0":               true,
+	"broadcasthost":         true,
+	"ip6-allhosts":          true,
+	"ip6-allnodes":          true,
+	"ip6-allrouters":        true,
+	"ip6-localhost":         true,
+	"ip6-localnet":          true,
+	"ip6-loopback":          true,
+	"ip6-mcastprefix":       true,
+	"local":                 true,
+	"localhost":             true,
+	"localhost.localdomain": true,
+}
+
+// BlocklistEntry is a single host pattern read from a blocklist.
+type BlocklistEntry struct {
+	Line    int    // Line number in the list, starting with 1
+	Host    string // Normalized host
+	Pattern string // Pattern matching the entry in the syntax of NewMatcher
+	Domain  string // Registrable domain of the host, empty if the host has none
+}
+
+// BlocklistError describes a malformed line of a blocklist.
+type BlocklistError struct {
+	Line   int    // Line number in the list, starting with 1
+	Text   string // Content of the line
+	Reason string // Reason of the failure
+}
+
+// Error implements the error interface.
+func (e *BlocklistError) Error() string {
+	return fmt.Sprintf("domainutil: line %d (%q): %s", e.Line, e.Text, e.Reason)
+}
+
+// BlocklistErrors is a list of malformed lines of a blocklist.
+type BlocklistErrors []*BlocklistError
+
+// Error implements the error interface.
+func (e BlocklistErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	return fmt.Sprintf("%s (and %d more errors)", e[0].Error(), len(e)-1)
+}
+
+// ParseBlocklist reads blocklist in provided format and returns its entries.
+//
+// Comments, empty lines and lines that do not describe hosts (such as other dnsmasq options,
+// Adblock cosmetic filters or Adblock rules limited by options or paths) are skipped.
+// Malformed lines are skipped as well and reported together as BlocklistErrors once the whole
+// list is read; the valid entries are returned in that case too.
+func ParseBlocklist(r io.Reader, format BlocklistFormat) ([]BlocklistEntry, error) {
+	return std().ParseBlocklist(r, format)
+}
+
+// ParseBlocklist reads blocklist in provided format and returns its entries, see the ParseBlocklist function.
+// Hosts are normalized and validated by the Parser.
+func (p *Parser) ParseBlocklist(r io.Reader, format BlocklistFormat) ([]BlocklistEntry, error) {
+	var parseLine func(line string) (entries []BlocklistEntry, reason string)
+	switch format {
+	case HostsFormat:
+		parseLine = p.parseHostsLine
+	case DnsmasqFormat:
+		parseLine = p.parseDnsmasqLine
+	case AdblockFormat:
+		parseLine = p.parseAdblockLine
+	default:
+		return nil, fmt.Errorf("domainutil: unknown blocklist format %d", format)
+	}
+
+	var entries []BlocklistEntry
+	var errs BlocklistErrors
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		parsed, reason := parseLine(text)
+		if reason != "" {
+			errs = append(errs, &BlocklistError{Line: line, Text: text, Reason: reason})
+			continue
+		}
+		for _, entry := range parsed {
+			entry.Line, entry.Domain = line, p.Domain(entry.Host)
+			entries = append(entries, entry)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return entries, err
+	}
+	if len(errs) > 0 {
+		return entries, errs
+	}
+	return entries, nil
+}
+
+// parseHostsLine parses line of a hosts file (address followed by host names).
+func (p *Parser) parseHostsLine(line string) ([]BlocklistEntry, string) {
+	if index := strings.Index(line, "#"); index > -1 {
+		line = line[:index]
+	}
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil, ""
+	}
+	if !isIP(fields[0]) {
+		return nil, "invalid IP address " + fields[0]
+	}
+	if len(fields) == 1 {
+		return nil, "missing host name"
+	}
+
+	var entries []BlocklistEntry
+	for _, name := range fields[1:] {
+		if ignoredHostsEntries[strings.ToLower(name)] {
+			continue
+		}
+		host, reason := p.blocklistHost(name)
+		if reason != "" {
+			return nil, reason
+		}
+		entries = append(entries, BlocklistEntry{Host: host, Pattern: "=" + host})
+	}
+	return entries, ""
+}
+
+// parseDnsmasqLine parses address=/domain/.../ip and local=/domain/.../ lines of dnsmasq configuration.
+func (p *Parser) parseDnsmasqLine(line string) ([]BlocklistEntry, string) {
+	var value string
+	switch {
+	case strings.HasPrefix(line, "address="):
+		value = line[len("address="):]
+	case strings.HasPrefix(line, "local="):
+		value = line[len("local="):]
+	default:
+		return nil, "" // Comment or option not describing hosts
+	}
+
+	// Domains are enclosed in slashes, the address follows the last slash
+	fields := strings.Split(value, "/")
+	if len(fields) < 3 || fields[0] != "" {
+		return nil, "domains must be enclosed in slashes"
+	}
+
+	var entries []BlocklistEntry
+	for _, name := range fields[1 : len(fields)-1] {
+		host, reason := p.blocklistHost(name)
+		if reason != "" {
+			return nil, reason
+		}
+		entries = append(entries, BlocklistEntry{Host: host, Pattern: "." + host})
+	}
+	return entries, ""
+}
+
+// parseAdblockLine parses ||domain^ and @@||domain^ host rules of Adblock Plus filter lists.
+// Valid rules blocking only some requests to a host (with options other than important, paths or wildcards) are skipped.
+func (p *Parser) parseAdblockLine(line string) ([]BlocklistEntry, string) {
+	// Skip comments, header & element hiding rules
+	if line == "" || strings.HasPrefix(line, "!") || strings.HasPrefix(line, "[") ||
+		strings.Contains(line, "##") || strings.Contains(line, "#@#") || strings.Contains(line, "#?#") {
+		return nil, ""
+	}
+
+	// Exceptions are turned into exception patterns
+	prefix := "."
+	if strings.HasPrefix(line, "@@") {
+		prefix, line = "!.", line[2:]
+	}
+
+	// Rules not anchored to a host (/banner/*) match parts of urls
+	if !strings.HasPrefix(line, "||") {
+		return nil, ""
+	}
+	line = line[2:]
+
+	// Only the important option keeps the rule a plain host rule
+	options := ""
+	if index := strings.LastIndex(line, "$"); index > -1 {
+		if line, options = line[:index], line[index+1:]; options == "" {
+			return nil, "empty rule options"
+		}
+	}
+
+	// Host ends with the first separator, wildcard or path
+	end := strings.IndexAny(line, "^|*/:")
+	if end == -1 {
+		end = len(line)
+	}
+	if end == 0 {
+		return nil, "missing host"
+	}
+	host, reason := p.blocklistHost(line[:end])
+	if reason != "" {
+		return nil, reason
+	}
+
+	if rest := line[end:]; rest != "^" && rest != "^|" || options != "" && options != "important" {
+		return nil, ""
+	}
+	return []BlocklistEntry{{Host: host, Pattern: prefix + host}}, ""
+}
+
+// blocklistHost normalizes & validates host name found in a blocklist.
+func (p *Parser) blocklistHost(name string) (string, string) {
+	if strings.ContainsAny(name, "/:@?#%[]") {
+		return "", "invalid host " + name
+	}
+	host := p.stripURLParts(name)
+
+	// Underscores are not valid in host names, but blocklists are full of them
+	if err := p.Validate(strings.Replace(host, "_", "a", -1)); err != nil {
+		return "", "invalid host " + name
+	}
+	return host, ""
+}
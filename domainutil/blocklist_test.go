@@ -0,0 +1,86 @@
+package domainutil
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func ExampleParseBlocklist() {
+	entries, _ := ParseBlocklist(strings.NewReader("||ads.google.com^\n@@||good.ads.google.com^\n"), AdblockFormat)
+	for _, entry := range entries {
+		fmt.Println(entry.Line, entry.Pattern, entry.Domain)
+	}
+	// Output: 1 .ads.google.com google.com
+	// 2 !.good.ads.google.com google.com
+}
+
+// TestParseBlocklist tests ParseBlocklist() function
+func TestParseBlocklist(t *testing.T) {
+	for _, testCase := range []struct {
+		Format   BlocklistFormat
+		List     string
+		Patterns []string
+		Errors   []int
+	}{
+		{
+			HostsFormat,
+			"# hosts\n127.0.0.1 localhost\n::1 localhost ip6-localhost\n\n0.0.0.0 ads.google.com tracker.Example.co.uk # trackers\n0.0.0.0 _dmarc.google.com\n0.0.0.0\nads.google.com\n0.0.0.0 bad/host.com\n0.0.0.0 xn--n3h.com.\n",
+			[]string{"=ads.google.com", "=tracker.example.co.uk", "=_dmarc.google.com", "=☃.com"},
+			[]int{7, 8, 9},
+		},
+		{
+			DnsmasqFormat,
+			"# dnsmasq\nserver=8.8.8.8\naddress=/ads.google.com/0.0.0.0\naddress=/a.com/b.co.uk/\nlocal=/tracker.example.com/\naddress=ads.google.com\naddress=/-bad.com/\n",
+			[]string{".ads.google.com", ".a.com", ".b.co.uk", ".tracker.example.com"},
+			[]int{6, 7},
+		},
+		{
+			AdblockFormat,
+			"[Adblock Plus 2.0]\n! comment\n||ads.google.com^\n||tracker.example.com^$important\n@@||good.ads.google.com^|\nexample.com##.banner\n||ads.google.com^$third-party\n/banner/*\n||ads.google.com/path^\n||ads*.example.com^\n||-bad.com^\n||^\n||ads.google.com^$\n@@||bad host.com^\n",
+			[]string{".ads.google.com", ".tracker.example.com", "!.good.ads.google.com"},
+			[]int{11, 12, 13, 14},
+		},
+	} {
+		entries, err := ParseBlocklist(strings.NewReader(testCase.List), testCase.Format)
+
+		var patterns []string
+		for _, entry := range entries {
+			patterns = append(patterns, entry.Pattern)
+		}
+		if !reflect.DeepEqual(patterns, testCase.Patterns) {
+			t.Errorf("Format %d returned %v for ParseBlocklist(), but %v was expected", testCase.Format, patterns, testCase.Patterns)
+		}
+
+		var lines []int
+		if errs, ok := err.(BlocklistErrors); ok {
+			for _, lineErr := range errs {
+				lines = append(lines, lineErr.Line)
+			}
+		} else if err != nil {
+			t.Errorf("Format %d returned unexpected error %v for ParseBlocklist()", testCase.Format, err)
+		}
+		if !reflect.DeepEqual(lines, testCase.Errors) {
+			t.Errorf("Format %d returned errors on lines %v for ParseBlocklist(), but %v was expected", testCase.Format, lines, testCase.Errors)
+		}
+	}
+}
+
+// TestParseBlocklistEntry tests fields of entries returned by ParseBlocklist() function
+func TestParseBlocklistEntry(t *testing.T) {
+	entries, err := ParseBlocklist(strings.NewReader("\n0.0.0.0 ads.google.co.uk localhost\n"), HostsFormat)
+	expected := []BlocklistEntry{{Line: 2, Host: "ads.google.co.uk", Pattern: "=ads.google.co.uk", Domain: "google.co.uk"}}
+	if err != nil || !reflect.DeepEqual(entries, expected) {
+		t.Errorf("ParseBlocklist() returned (%v, %v), but (%v, nil) was expected", entries, err, expected)
+	}
+
+	entries, _ = NewParser(WithICANNOnly(true)).ParseBlocklist(strings.NewReader("||ads.foo.blogspot.com^\n"), AdblockFormat)
+	if len(entries) != 1 || entries[0].Domain != "blogspot.com" {
+		t.Errorf("Parser.ParseBlocklist() returned %v, but entry with domain %q was expected", entries, "blogspot.com")
+	}
+
+	if _, err := ParseBlocklist(strings.NewReader(""), BlocklistFormat(42)); err == nil {
+		t.Errorf("ParseBlocklist() accepted unknown format")
+	}
+}
@@ -0,0 +1,147 @@
+package domainutil
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+)
+
+// cacheShards is the number of independently locked parts of a Cache
+const cacheShards = 16
+
+// CacheStats holds statistics of a Cache.
+type CacheStats struct {
+	Hits   uint64 // Number of lookups served from the cache
+	Misses uint64 // Number of lookups computed & added to the cache
+	Size   int    // Number of cached hosts
+}
+
+// Cache is a bounded, sharded LRU cache of hosts split on their public suffix boundary.
+// Hosts are cached together with the Parser options they were split with, so a cache may be shared by parsers.
+// Cache is safe for concurrent use.
+type Cache struct {
+	shards [cacheShards]cacheShard
+	hits   uint64
+	misses uint64
+}
+
+// cacheShard is a single LRU list
+type cacheShard struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[cacheKey]*list.Element
+	lru      *list.List // Most recently used entries in front
+}
+
+// cacheKey identifies raw host split with the options
+type cacheKey struct {
+	host    string
+	options parserOptions
+}
+
+// cacheEntry is a single cached host
+type cacheEntry struct {
+	key   cacheKey
+	parts hostParts
+}
+
+// NewCache creates cache holding roughly capacity hosts.
+// The capacity is split evenly among the shards of the cache, each shard holds at least one host.
+// Cache with capacity less than one holds no hosts, so it disables caching.
+func NewCache(capacity int) *Cache {
+	perShard := 0
+	if capacity > 0 {
+		perShard = (capacity + cacheShards - 1) / cacheShards
+	}
+
+	c := &Cache{}
+	for i := range c.shards {
+		c.shards[i].capacity = perShard
+		c.shards[i].entries = map[cacheKey]*list.Element{}
+		c.shards[i].lru = list.New()
+	}
+	return c
+}
+
+// SetCache sets cache used by the package functions, nil disables caching.
+func SetCache(c *Cache) {
+	updateDefaultParser(WithCache(c))
+}
+
+// Stats returns statistics of the cache.
+func (c *Cache) Stats() CacheStats {
+	stats := CacheStats{Hits: atomic.LoadUint64(&c.hits), Misses: atomic.LoadUint64(&c.misses)}
+	for i := range c.shards {
+		shard := &c.shards[i]
+		shard.mu.Lock()
+		stats.Size += shard.lru.Len()
+		shard.mu.Unlock()
+	}
+	return stats
+}
+
+// Purge removes all hosts from the cache.
+func (c *Cache) Purge() {
+	for i := range c.shards {
+		shard := &c.shards[i]
+		shard.mu.Lock()
+		shard.purge()
+		shard.mu.Unlock()
+	}
+}
+
+// get returns cached parts of the raw host computed with the options.
+func (c *Cache) get(host string, options parserOptions) (hostParts, bool) {
+	shard := c.shard(host)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	element, found := shard.entries[cacheKey{host, options}]
+	if !found {
+		atomic.AddUint64(&c.misses, 1)
+		return hostParts{}, false
+	}
+	atomic.AddUint64(&c.hits, 1)
+	shard.lru.MoveToFront(element)
+	return element.Value.(*cacheEntry).parts, true
+}
+
+// add caches parts of the raw host computed with the options, evicting the least recently used host if needed.
+func (c *Cache) add(host string, parts hostParts, options parserOptions) {
+	shard := c.shard(host)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	if shard.capacity == 0 {
+		return
+	}
+	key := cacheKey{host, options}
+	if element, found := shard.entries[key]; found {
+		element.Value.(*cacheEntry).parts = parts
+		shard.lru.MoveToFront(element)
+		return
+	}
+	if shard.lru.Len() >= shard.capacity {
+		oldest := shard.lru.Back()
+		shard.lru.Remove(oldest)
+		delete(shard.entries, oldest.Value.(*cacheEntry).key)
+	}
+	shard.entries[key] = shard.lru.PushFront(&cacheEntry{key: key, parts: parts})
+}
+
+// shard returns shard holding the host.
+func (c *Cache) shard(host string) *cacheShard {
+	// FNV-1a hash
+	hash := uint32(2166136261)
+	for i := 0; i < len(host); i++ {
+		hash ^= uint32(host[i])
+		hash *= 16777619
+	}
+	return &c.shards[hash%cacheShards]
+}
+
+// purge removes all entries of the shard. Caller must hold the lock.
+func (s *cacheShard) purge() {
+	s.entries = map[cacheKey]*list.Element{}
+	s.lru.Init()
+}
@@ -0,0 +1,143 @@
+package domainutil
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func ExampleCache() {
+	cache := NewCache(1000)
+	SetCache(cache)
+	defer SetCache(nil)
+
+	Domain("https://keep.google.com/u/0/")
+	Subdomain("https://keep.google.com/u/1/")
+	fmt.Printf("%+v\n", cache.Stats())
+	// Output: {Hits:1 Misses:1 Size:1}
+}
+
+// TestCache tests that cached results match uncached ones
+func TestCache(t *testing.T) {
+	cache := NewCache(1000)
+	SetCache(cache)
+	defer SetCache(nil)
+
+	urls := []string{"google.com", "keep.google.com", "KEEP.Google.com.", "beta.gama.google.co.uk", "nonexist.***", "xn--n3h.com", "", "foo.bar.ck"}
+	for round := 0; round < 3; round++ {
+		for _, url := range urls {
+			SetCache(cache)
+			cached := std().split(url)
+			SetCache(nil)
+			if uncached := std().split(url); cached != uncached {
+				t.Errorf("Url (%q) returned %+v from cache, but %+v was expected", url, cached, uncached)
+			}
+		}
+	}
+
+	if stats := cache.Stats(); stats.Misses != uint64(len(urls)) || stats.Hits != uint64(2*len(urls)) {
+		t.Errorf("Cache.Stats() returned %+v, but %d hits and %d misses were expected", stats, 2*len(urls), len(urls))
+	}
+
+	cache.Purge()
+	if stats := cache.Stats(); stats.Size != 0 {
+		t.Errorf("Cache.Stats() returned %+v after Purge(), but empty cache was expected", stats)
+	}
+}
+
+// TestCacheEviction tests that cache stays within its capacity
+func TestCacheEviction(t *testing.T) {
+	cache := NewCache(32)
+	SetCache(cache)
+	defer SetCache(nil)
+
+	for i := 0; i < 1000; i++ {
+		Domain("host" + strconv.Itoa(i) + ".google.com")
+	}
+	if stats := cache.Stats(); stats.Size > 32 || stats.Size == 0 {
+		t.Errorf("Cache.Stats() returned %+v, but at most 32 hosts were expected", stats)
+	}
+}
+
+// TestCacheInvalidation tests that cached hosts are not reused when suffix list or strict mode changes
+func TestCacheInvalidation(t *testing.T) {
+	SetCache(NewCache(100))
+	defer SetCache(nil)
+	defer SetSuffixList(nil)
+	defer SetStrict(false)
+
+	if result := Domain("wiki.intranet.corp"); result != "" {
+		t.Errorf("Domain() returned %q, but empty string was expected", result)
+	}
+	list, _ := ReadSuffixList(strings.NewReader("corp\n"))
+	SetSuffixList(list)
+	if result := Domain("wiki.intranet.corp"); result != "intranet.corp" {
+		t.Errorf("Domain() returned %q after list change, but %q was expected", result, "intranet.corp")
+	}
+	SetSuffixList(nil)
+	if result := Domain("goo_gle.com"); result != "goo_gle.com" {
+		t.Errorf("Domain() returned %q, but %q was expected", result, "goo_gle.com")
+	}
+	SetStrict(true)
+	if result := Domain("goo_gle.com"); result != "" {
+		t.Errorf("Domain() returned %q in strict mode, but empty string was expected", result)
+	}
+}
+
+// TestCacheShared tests cache shared by parsers with different options
+func TestCacheShared(t *testing.T) {
+	cache := NewCache(100)
+	all, icann := NewParser(WithCache(cache)), NewParser(WithCache(cache), WithICANNOnly(true))
+	for round := 0; round < 3; round++ {
+		if result := all.Domain("bobesa.github.io"); result != "bobesa.github.io" {
+			t.Errorf("Url (%q) returned %q for Domain(), but %q was expected", "bobesa.github.io", result, "bobesa.github.io")
+		}
+		if result := icann.Domain("bobesa.github.io"); result != "github.io" {
+			t.Errorf("Url (%q) returned %q for Domain() of ICANN only parser, but %q was expected", "bobesa.github.io", result, "github.io")
+		}
+	}
+	if stats := cache.Stats(); stats.Hits != 4 || stats.Misses != 2 || stats.Size != 2 {
+		t.Errorf("Cache.Stats() returned %+v, but 4 hits, 2 misses and 2 hosts were expected", stats)
+	}
+
+	disabled := NewCache(0)
+	parser := NewParser(WithCache(disabled))
+	for round := 0; round < 3; round++ {
+		parser.Domain("keep.google.com")
+	}
+	if stats := disabled.Stats(); stats.Hits != 0 || stats.Size != 0 {
+		t.Errorf("Cache.Stats() returned %+v for zero capacity, but empty cache was expected", stats)
+	}
+}
+
+// TestCacheConcurrent tests concurrent use of the cache
+func TestCacheConcurrent(t *testing.T) {
+	SetCache(NewCache(64))
+	defer SetCache(nil)
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < 8; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				host := "host" + strconv.Itoa((i*worker)%100) + ".google.co.uk"
+				if result := Domain(host); result != "google.co.uk" {
+					t.Errorf("Url (%q) returned %q for Domain(), but %q was expected", host, result, "google.co.uk")
+				}
+			}
+		}(worker)
+	}
+	wg.Wait()
+}
+
+// BenchmarkCachedDomain benchmarks Domain() function with cache enabled
+func BenchmarkCachedDomain(b *testing.B) {
+	SetCache(NewCache(1000))
+	defer SetCache(nil)
+	for i := 0; i < b.N; i++ {
+		Domain("https://beta.gama.google.co.uk?test=true")
+	}
+}
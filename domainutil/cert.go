@@ -0,0 +1,76 @@
+package domainutil
+
+import (
+	"crypto/x509"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// MatchCertificateName reports whether host matches the DNS name pattern of a TLS certificate (RFC 6125).
+//
+// Both host and pattern are compared in their lower cased A-label form. A wildcard may only be
+// the whole left-most label of pattern and matches exactly one label. Wildcards at or directly above
+// a public suffix (*.com, *.co.uk) never match. IP addresses never match wildcards.
+func MatchCertificateName(host, pattern string) bool {
+	return std().MatchCertificateName(host, pattern)
+}
+
+// CertificateDomains returns sorted list of registrable domains covered by DNS names of the certificate.
+// Names without registrable domain are left out.
+func CertificateDomains(cert *x509.Certificate) []string {
+	return std().CertificateDomains(cert)
+}
+
+// MatchCertificateName reports whether host matches the DNS name pattern of a TLS certificate, see the MatchCertificateName function.
+// Public suffixes are looked up by the options of the Parser.
+func (p *Parser) MatchCertificateName(host, pattern string) bool {
+	host, pattern = certificateName(p.stripURLParts(host)), certificateName(pattern)
+	if host == "" || pattern == "" {
+		return false
+	}
+	if !strings.HasPrefix(pattern, "*.") {
+		return host == pattern
+	}
+
+	// Check the part covered by the wildcard
+	parent := pattern[2:]
+	if strings.Contains(parent, "*") || !strings.Contains(parent, ".") || isIP(host) {
+		return false
+	}
+	if unicode, err := idna.ToUnicode(parent); err != nil || p.isSuffix(unicode) {
+		return false
+	}
+
+	// Wildcard replaces exactly one non-empty label
+	index := strings.Index(host, ".")
+	return index > 0 && host[index+1:] == parent
+}
+
+// CertificateDomains returns sorted list of registrable domains covered by DNS names of the certificate, see the CertificateDomains function.
+func (p *Parser) CertificateDomains(cert *x509.Certificate) []string {
+	found := map[string]bool{}
+	for _, name := range cert.DNSNames {
+		if domain := p.Domain(strings.TrimPrefix(name, "*.")); domain != "" {
+			found[domain] = true
+		}
+	}
+
+	domains := make([]string, 0, len(found))
+	for domain := range found {
+		domains = append(domains, domain)
+	}
+	sort.Strings(domains)
+	return domains
+}
+
+// certificateName returns name in lower cased A-label form without the trailing dot.
+// If name cannot be converted, this function returns empty string.
+func certificateName(name string) string {
+	name, err := idna.ToASCII(strings.ToLower(strings.TrimSuffix(name, ".")))
+	if err != nil {
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,74 @@
+package domainutil
+
+import (
+	"crypto/x509"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func ExampleMatchCertificateName() {
+	fmt.Println(MatchCertificateName("keep.google.com", "*.google.com"))
+	fmt.Println(MatchCertificateName("google.co.uk", "*.co.uk"))
+	// Output: true
+	// false
+}
+
+// TestMatchCertificateName tests MatchCertificateName() function
+func TestMatchCertificateName(t *testing.T) {
+	for _, testCase := range []struct {
+		Host, Pattern string
+		Expected      bool
+	}{
+		{"google.com", "google.com", true},
+		{"GOOGLE.com.", "google.COM", true},
+		{"https://keep.google.com/path", "keep.google.com", true},
+		{"keep.google.com", "*.google.com", true},
+		{"keep.google.com", "*.GOOGLE.com.", true},
+		{"google.com", "*.google.com", false},
+		{"a.keep.google.com", "*.google.com", false},
+		{"keep.google.co.uk", "*.google.co.uk", true},
+		{"google.co.uk", "*.co.uk", false},
+		{"google.com", "*.com", false},
+		{"google.github.io", "*.github.io", false},
+		{"foo.bar.ck", "*.bar.ck", false},
+		{"localhost", "*", false},
+		{"keep.google.com", "k*.google.com", false},
+		{"keep.google.com", "*.*.com", false},
+		{"☃.example.com", "xn--n3h.example.com", true},
+		{"xn--n3h.example.com", "☃.example.com", true},
+		{"☃.example.com", "*.example.com", true},
+		{"keep.müller.de", "*.xn--mller-kva.de", true},
+		{"192.168.0.1", "192.168.0.1", true},
+		{"", "", false},
+	} {
+		if result := MatchCertificateName(testCase.Host, testCase.Pattern); result != testCase.Expected {
+			t.Errorf("Host (%q) returned %v for MatchCertificateName(%q), but %v was expected", testCase.Host, result, testCase.Pattern, testCase.Expected)
+		}
+	}
+}
+
+// BenchmarkMatchCertificateName benchmarks MatchCertificateName() function
+func BenchmarkMatchCertificateName(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		MatchCertificateName("beta.gama.google.co.uk", "*.gama.google.co.uk")
+	}
+}
+
+// TestCertificateDomains tests CertificateDomains() function
+func TestCertificateDomains(t *testing.T) {
+	cert := &x509.Certificate{DNSNames: []string{
+		"google.com",
+		"*.google.com",
+		"*.keep.google.com",
+		"google.co.uk",
+		"*.google.co.uk",
+		"xn--n3h.com",
+		"localhost",
+		"co.uk",
+	}}
+	expected := []string{"google.co.uk", "google.com", "☃.com"}
+	if domains := CertificateDomains(cert); !reflect.DeepEqual(domains, expected) {
+		t.Errorf("CertificateDomains() returned %v, but %v was expected", domains, expected)
+	}
+}
@@ -0,0 +1,74 @@
+package domainutil
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrCookieDomainIP is returned when a Domain attribute is set by an IP address host
+	ErrCookieDomainIP = errors.New("domainutil: cookie domain attribute is not allowed for IP address hosts")
+	// ErrCookieDomainPublicSuffix is returned when a Domain attribute is a public suffix
+	ErrCookieDomainPublicSuffix = errors.New("domainutil: cookie domain attribute is a public suffix")
+	// ErrCookieDomainMismatch is returned when the request host does not domain-match a Domain attribute
+	ErrCookieDomainMismatch = errors.New("domainutil: request host does not domain-match cookie domain attribute")
+)
+
+// CookieDomainMatch reports whether host domain-matches cookieDomain as defined in RFC 6265 section 5.1.3.
+// Both values are canonicalized first and the leading dot of cookieDomain is ignored.
+func CookieDomainMatch(host, cookieDomain string) bool {
+	return std().CookieDomainMatch(host, cookieDomain)
+}
+
+// ValidateCookieDomain checks the Domain attribute attr of a cookie received from requestHost
+// as described in RFC 6265 sections 5.2.3 and 5.3.
+//
+// It returns the canonical domain the cookie applies to. Empty domain means the cookie
+// is a host-only cookie, which is the case for an empty attribute or an attribute equal to
+// a request host that is a public suffix itself. The cookie must be ignored if an error is returned.
+func ValidateCookieDomain(requestHost, attr string) (string, error) {
+	return std().ValidateCookieDomain(requestHost, attr)
+}
+
+// CookieDomainMatch reports whether host domain-matches cookieDomain, see the CookieDomainMatch function.
+func (p *Parser) CookieDomainMatch(host, cookieDomain string) bool {
+	host, cookieDomain = p.stripURLParts(host), p.cookieDomainValue(cookieDomain)
+	if host == "" || cookieDomain == "" {
+		return false
+	}
+	if host == cookieDomain {
+		return true
+	}
+	return !isIP(host) && strings.HasSuffix(host, "."+cookieDomain)
+}
+
+// ValidateCookieDomain checks the Domain attribute attr of a cookie received from requestHost,
+// see the ValidateCookieDomain function. Public suffixes are looked up by the options of the Parser.
+func (p *Parser) ValidateCookieDomain(requestHost, attr string) (string, error) {
+	host, domain := p.stripURLParts(requestHost), p.cookieDomainValue(attr)
+	if domain == "" {
+		return "", nil
+	}
+	if isIP(host) {
+		return "", ErrCookieDomainIP
+	}
+	if p.isSuffix(domain) {
+		if domain == host {
+			return "", nil
+		}
+		return "", ErrCookieDomainPublicSuffix
+	}
+	if !p.CookieDomainMatch(host, domain) {
+		return "", ErrCookieDomainMismatch
+	}
+	return domain, nil
+}
+
+// cookieDomainValue canonicalizes value of the Domain attribute.
+func (p *Parser) cookieDomainValue(attr string) string {
+	attr = strings.TrimPrefix(strings.TrimSpace(attr), ".")
+	if attr == "" {
+		return ""
+	}
+	return p.stripURLParts(attr)
+}
@@ -0,0 +1,69 @@
+package domainutil
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExampleValidateCookieDomain() {
+	fmt.Println(ValidateCookieDomain("keep.google.com", ".google.com"))
+	fmt.Println(ValidateCookieDomain("keep.google.co.uk", "co.uk"))
+	// Output: google.com <nil>
+	//  domainutil: cookie domain attribute is a public suffix
+}
+
+// TestCookieDomainMatch tests CookieDomainMatch() function
+func TestCookieDomainMatch(t *testing.T) {
+	for _, testCase := range []struct {
+		Host, CookieDomain string
+		Expected           bool
+	}{
+		{"google.com", "google.com", true},
+		{"google.com", ".google.com", true},
+		{"keep.google.com", "google.com", true},
+		{"KEEP.Google.com", ".GOOGLE.com", true},
+		{"https://keep.google.com/path", "google.com", true},
+		{"a.b.keep.google.com", "keep.google.com", true},
+		{"google.com", "keep.google.com", false},
+		{"evil-google.com", "google.com", false},
+		{"google.com.evil.com", "google.com", false},
+		{"192.168.0.1", "192.168.0.1", true},
+		{"192.168.0.1", "168.0.1", false},
+		{"google.com", "", false},
+		{"", "google.com", false},
+	} {
+		if result := CookieDomainMatch(testCase.Host, testCase.CookieDomain); result != testCase.Expected {
+			t.Errorf("Host (%q) returned %v for CookieDomainMatch(%q), but %v was expected", testCase.Host, result, testCase.CookieDomain, testCase.Expected)
+		}
+	}
+}
+
+// TestValidateCookieDomain tests ValidateCookieDomain() function
+func TestValidateCookieDomain(t *testing.T) {
+	for _, testCase := range []struct {
+		Host, Attr, Domain string
+		Err                error
+	}{
+		{"keep.google.com", "", "", nil},
+		{"keep.google.com", ".", "", nil},
+		{"keep.google.com", "google.com", "google.com", nil},
+		{"keep.google.com", ".google.com", "google.com", nil},
+		{"keep.google.com", "Keep.Google.com", "keep.google.com", nil},
+		{"keep.google.co.uk", "google.co.uk", "google.co.uk", nil},
+		{"keep.google.com", "mail.google.com", "", ErrCookieDomainMismatch},
+		{"keep.google.com", "evil.com", "", ErrCookieDomainMismatch},
+		{"keep.google.com", "com", "", ErrCookieDomainPublicSuffix},
+		{"keep.google.co.uk", ".co.uk", "", ErrCookieDomainPublicSuffix},
+		{"keep.google.github.io", "github.io", "", ErrCookieDomainPublicSuffix},
+		{"github.io", "github.io", "", nil},
+		{"www.ck", "www.ck", "www.ck", nil},
+		{"foo.bar.ck", "bar.ck", "", ErrCookieDomainPublicSuffix},
+		{"192.168.0.1", "192.168.0.1", "", ErrCookieDomainIP},
+		{"192.168.0.1", "", "", nil},
+	} {
+		domain, err := ValidateCookieDomain(testCase.Host, testCase.Attr)
+		if domain != testCase.Domain || err != testCase.Err {
+			t.Errorf("Host (%q) returned (%q, %v) for ValidateCookieDomain(%q), but (%q, %v) was expected", testCase.Host, domain, err, testCase.Attr, testCase.Domain, testCase.Err)
+		}
+	}
+}
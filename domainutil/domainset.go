@@ -0,0 +1,427 @@
+package domainutil
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// domainSetBlockSize is the number of keys front-coded against each other
+const domainSetBlockSize = 16
+
+// domainSetMinPending is the number of sorted pending keys kept before they are packed,
+// larger sets keep up to square root of their length
+const domainSetMinPending = 256
+
+// domainSetMagic starts binary form of DomainSet (including version)
+const domainSetMagic = "DSET\x01"
+
+// errDomainSetCorrupted is returned when binary form of DomainSet cannot be decoded
+var errDomainSetCorrupted = errors.New("domainutil: corrupted domain set")
+
+// DomainSet is a memory efficient set of hosts.
+//
+// Hosts are kept as label-reversed keys (www.example.com is stored as com.example.www), sorted
+// and front-coded in blocks of 16 keys, so hosts sharing a parent share the memory for it.
+// Lookups binary search the blocks and scan a single block.
+//
+// Added hosts are kept aside and sorted by the next lookup. They are packed once there are more of them
+// than the square root of the set length, so adding hosts in between lookups does not repack the whole set.
+//
+// The zero value is an empty set ready to use, normalizing hosts by the default parser. Parser.NewDomainSet
+// returns a set normalizing hosts by other parser. DomainSet is safe for concurrent use.
+type DomainSet struct {
+	parser  *Parser // Parser normalizing hosts, nil for the default one
+	mu      sync.RWMutex
+	added   []string // Keys added since the last lookup, unsorted
+	pending []string // Sorted keys not packed yet, none of them is packed
+	data    []byte   // Front-coded keys
+	blocks  []uint32 // Offsets of blocks in data
+	length  int      // Number of packed keys
+}
+
+// ReadDomainSet builds DomainSet from r containing one host (or url) per line.
+// Empty lines and lines starting with # are skipped.
+func ReadDomainSet(r io.Reader) (*DomainSet, error) {
+	return readDomainSet(r, &DomainSet{})
+}
+
+// NewDomainSet returns an empty DomainSet normalizing hosts by the Parser.
+func (p *Parser) NewDomainSet() *DomainSet {
+	return &DomainSet{parser: p}
+}
+
+// ReadDomainSet builds DomainSet normalizing hosts by the Parser, see the ReadDomainSet function.
+func (p *Parser) ReadDomainSet(r io.Reader) (*DomainSet, error) {
+	return readDomainSet(r, p.NewDomainSet())
+}
+
+// readDomainSet adds hosts read from r to set.
+func readDomainSet(r io.Reader, set *DomainSet) (*DomainSet, error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		set.Add(line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// Add adds host of provided url to the set.
+// Urls without host are ignored.
+func (s *DomainSet) Add(url string) {
+	host := s.stripURLParts(url)
+	if host == "" {
+		return
+	}
+
+	s.mu.Lock()
+	s.added = append(s.added, reverseLabels(host))
+	s.mu.Unlock()
+}
+
+// Len returns number of hosts in the set.
+func (s *DomainSet) Len() int {
+	s.rlock()
+	defer s.mu.RUnlock()
+	return s.length + len(s.pending)
+}
+
+// Contains reports whether host of provided url is in the set.
+func (s *DomainSet) Contains(url string) bool {
+	host := s.stripURLParts(url)
+	if host == "" {
+		return false
+	}
+
+	s.rlock()
+	defer s.mu.RUnlock()
+	return s.lookup(reverseLabels(host))
+}
+
+// ContainsOrParent reports whether host of provided url or any of its parents is in the set.
+func (s *DomainSet) ContainsOrParent(url string) bool {
+	_, found := s.LongestMatch(url)
+	return found
+}
+
+// LongestMatch returns the longest host in the set that is either the host of provided url or one of its parents.
+// For example if the set holds example.com and www.example.com, LongestMatch("a.www.example.com") returns www.example.com.
+func (s *DomainSet) LongestMatch(url string) (string, bool) {
+	host := s.stripURLParts(url)
+	if host == "" {
+		return "", false
+	}
+
+	s.rlock()
+	defer s.mu.RUnlock()
+
+	// Reversed keys of the parents are prefixes of the reversed key (com.example.www -> com.example -> com)
+	key := reverseLabels(host)
+	for {
+		if s.lookup(key) {
+			return host[len(host)-len(key):], true
+		}
+		index := strings.LastIndex(key, ".")
+		if index == -1 {
+			return "", false
+		}
+		key = key[:index]
+	}
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The binary form can be loaded without rebuilding the set.
+func (s *DomainSet) MarshalBinary() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.flush()
+	s.pack()
+
+	out := make([]byte, 0, len(domainSetMagic)+len(s.data)+4*len(s.blocks)+3*binary.MaxVarintLen64)
+	out = append(out, domainSetMagic...)
+	out = appendUvarint(out, uint64(s.length))
+	out = appendUvarint(out, uint64(len(s.data)))
+	out = append(out, s.data...)
+	out = appendUvarint(out, uint64(len(s.blocks)))
+	for _, offset := range s.blocks {
+		out = append(out, byte(offset), byte(offset>>8), byte(offset>>16), byte(offset>>24))
+	}
+	return out, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// It replaces contents of the set with the set encoded by MarshalBinary.
+func (s *DomainSet) UnmarshalBinary(data []byte) error {
+	if !strings.HasPrefix(string(data), domainSetMagic) {
+		return errDomainSetCorrupted
+	}
+	data = data[len(domainSetMagic):]
+
+	// Read header & keys
+	length, n := binary.Uvarint(data)
+	if n <= 0 {
+		return errDomainSetCorrupted
+	}
+	data = data[n:]
+	size, n := binary.Uvarint(data)
+	if n <= 0 || uint64(len(data)-n) < size {
+		return errDomainSetCorrupted
+	}
+	keys := append([]byte(nil), data[n:n+int(size)]...)
+	data = data[n+int(size):]
+
+	// Read block offsets
+	count, n := binary.Uvarint(data)
+	if n <= 0 || count > uint64(len(data)-n)/4 || uint64(len(data)-n) != 4*count {
+		return errDomainSetCorrupted
+	}
+	data = data[n:]
+	blocks := make([]uint32, count)
+	for i := range blocks {
+		blocks[i] = binary.LittleEndian.Uint32(data[4*i:])
+	}
+
+	// Verify the keys decode before trusting them
+	if err := verifyFrontCoded(keys, blocks, length); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.added, s.pending, s.data, s.blocks, s.length = nil, nil, keys, blocks, int(length)
+	s.mu.Unlock()
+	return nil
+}
+
+// verifyFrontCoded checks that keys split into blocks decode into length sorted keys.
+// Each block must decode on its own from its offset, so its first key cannot share a prefix.
+func verifyFrontCoded(keys []byte, blocks []uint32, length uint64) error {
+	if len(blocks) == 0 && len(keys) > 0 || len(blocks) > 0 && blocks[0] != 0 {
+		return errDomainSetCorrupted
+	}
+
+	decoded, previous := uint64(0), []byte(nil)
+	for i, offset := range blocks {
+		end := len(keys)
+		if i+1 < len(blocks) {
+			end = int(blocks[i+1])
+		}
+		if int(offset) >= end {
+			return errDomainSetCorrupted
+		}
+
+		sorted := true
+		err := eachFrontCoded(keys, int(offset), end, func(key []byte) bool {
+			sorted = decoded == 0 || string(previous) < string(key)
+			previous = append(previous[:0], key...)
+			decoded++
+			return sorted
+		})
+		if err != nil || !sorted {
+			return errDomainSetCorrupted
+		}
+	}
+	if decoded != length {
+		return errDomainSetCorrupted
+	}
+	return nil
+}
+
+// stripURLParts returns cleaned host of url by the Parser of the set.
+func (s *DomainSet) stripURLParts(url string) string {
+	if s.parser == nil {
+		return stripURLParts(url)
+	}
+	return s.parser.stripURLParts(url)
+}
+
+// rlock read locks the set, sorting added keys first.
+func (s *DomainSet) rlock() {
+	s.mu.RLock()
+	for len(s.added) > 0 {
+		s.mu.RUnlock()
+		s.mu.Lock()
+		s.flush()
+		s.mu.Unlock()
+		s.mu.RLock()
+	}
+}
+
+// flush merges added keys into the sorted pending keys and packs them once there are too many.
+// Caller must hold the write lock.
+func (s *DomainSet) flush() {
+	if len(s.added) == 0 {
+		return
+	}
+	sort.Strings(s.added)
+
+	// Merge pending & added keys, dropping duplicates & keys already packed
+	keys := make([]string, 0, len(s.pending)+len(s.added))
+	pending, added := s.pending, s.added
+	for len(pending) > 0 || len(added) > 0 {
+		var key string
+		if len(added) == 0 || len(pending) > 0 && pending[0] <= added[0] {
+			key, pending = pending[0], pending[1:]
+		} else {
+			key, added = added[0], added[1:]
+			if s.lookupPacked(key) {
+				continue
+			}
+		}
+		if len(keys) == 0 || keys[len(keys)-1] != key {
+			keys = append(keys, key)
+		}
+	}
+	s.added, s.pending = nil, keys
+
+	if len(s.pending) >= domainSetMinPending && len(s.pending)*len(s.pending) >= s.length {
+		s.pack()
+	}
+}
+
+// pack merges sorted pending keys into the front-coded keys. Caller must hold the write lock.
+func (s *DomainSet) pack() {
+	if len(s.pending) == 0 {
+		return
+	}
+
+	// Merge packed & pending keys, dropping duplicates
+	keys := make([]string, 0, s.length+len(s.pending))
+	add := func(key string) {
+		if len(keys) == 0 || keys[len(keys)-1] != key {
+			keys = append(keys, key)
+		}
+	}
+	pending := s.pending
+	eachFrontCoded(s.data, 0, len(s.data), func(key []byte) bool {
+		for len(pending) > 0 && pending[0] < string(key) {
+			add(pending[0])
+			pending = pending[1:]
+		}
+		add(string(key))
+		return true
+	})
+	for _, key := range pending {
+		add(key)
+	}
+
+	// Encode the keys
+	data, blocks := make([]byte, 0, len(s.data)+8*len(s.pending)), make([]uint32, 0, len(keys)/domainSetBlockSize+1)
+	for i, key := range keys {
+		shared := 0
+		if i%domainSetBlockSize == 0 {
+			blocks = append(blocks, uint32(len(data)))
+		} else {
+			shared = commonPrefixLength(keys[i-1], key)
+		}
+		data = appendUvarint(data, uint64(shared))
+		data = appendUvarint(data, uint64(len(key)-shared))
+		data = append(data, key[shared:]...)
+	}
+
+	s.pending, s.data, s.blocks, s.length = nil, data, blocks, len(keys)
+}
+
+// lookup reports whether key is in the set. Caller must hold the read lock.
+func (s *DomainSet) lookup(key string) bool {
+	if index := sort.SearchStrings(s.pending, key); index < len(s.pending) && s.pending[index] == key {
+		return true
+	}
+	return s.lookupPacked(key)
+}
+
+// lookupPacked reports whether key is packed in the set. Caller must hold the read lock.
+func (s *DomainSet) lookupPacked(key string) bool {
+	// Find the last block starting with key not greater than the key
+	block := sort.Search(len(s.blocks), func(i int) bool {
+		return string(s.blockHead(i)) > key
+	}) - 1
+	if block < 0 {
+		return false
+	}
+
+	// Scan the block
+	end := len(s.data)
+	if block+1 < len(s.blocks) {
+		end = int(s.blocks[block+1])
+	}
+	found := false
+	eachFrontCoded(s.data, int(s.blocks[block]), end, func(current []byte) bool {
+		found = string(current) == key
+		return !found && string(current) < key
+	})
+	return found
+}
+
+// blockHead returns the first key of the block.
+func (s *DomainSet) blockHead(block int) []byte {
+	pos := int(s.blocks[block])
+	_, n := binary.Uvarint(s.data[pos:]) // Shared prefix is always empty
+	pos += n
+	length, n := binary.Uvarint(s.data[pos:])
+	pos += n
+	return s.data[pos : pos+int(length)]
+}
+
+// eachFrontCoded calls fn for each front-coded key in data[start:end] until fn returns false.
+// The key passed to fn is only valid until fn returns.
+func eachFrontCoded(data []byte, start, end int, fn func(key []byte) bool) error {
+	var key []byte
+	for pos := start; pos < end; {
+		shared, n := binary.Uvarint(data[pos:end])
+		if n <= 0 || shared > uint64(len(key)) {
+			return errDomainSetCorrupted
+		}
+		pos += n
+		length, n := binary.Uvarint(data[pos:end])
+		if n <= 0 || length > uint64(end-pos-n) {
+			return errDomainSetCorrupted
+		}
+		pos += n
+		key = append(key[:shared], data[pos:pos+int(length)]...)
+		pos += int(length)
+		if !fn(key) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// reverseLabels reverses order of labels in host (www.example.com becomes com.example.www).
+func reverseLabels(host string) string {
+	var builder strings.Builder
+	builder.Grow(len(host))
+	for end := len(host); end >= 0; {
+		start := strings.LastIndex(host[:end], ".")
+		builder.WriteString(host[start+1 : end])
+		if start >= 0 {
+			builder.WriteByte('.')
+		}
+		end = start
+	}
+	return builder.String()
+}
+
+// commonPrefixLength returns length of the common prefix of a and b.
+func commonPrefixLength(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+// appendUvarint appends varint encoded x to buf.
+func appendUvarint(buf []byte, x uint64) []byte {
+	var encoded [binary.MaxVarintLen64]byte
+	return append(buf, encoded[:binary.PutUvarint(encoded[:], x)]...)
+}
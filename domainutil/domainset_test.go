@@ -0,0 +1,160 @@
+package domainutil
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func ExampleDomainSet() {
+	set, _ := ReadDomainSet(strings.NewReader("# blocklist\nads.google.com\ntracker.example.com\n"))
+	fmt.Println(set.Contains("https://ads.google.com/pixel.gif"))
+	fmt.Println(set.ContainsOrParent("https://eu.ads.google.com/pixel.gif"))
+	fmt.Println(set.ContainsOrParent("https://google.com/"))
+	// Output: true
+	// true
+	// false
+}
+
+// TestDomainSet tests DomainSet lookups
+func TestDomainSet(t *testing.T) {
+	set := &DomainSet{}
+	for _, host := range []string{"google.com", "ads.google.com", "ads.google.com", "HTTPS://Tracker.Example.COM/", "☃.com", "co.uk", ""} {
+		set.Add(host)
+	}
+	for i := 0; i < 100; i++ {
+		set.Add("host" + strconv.Itoa(i) + ".example.org")
+	}
+	if set.Len() != 105 {
+		t.Errorf("DomainSet.Len() returned %d, but 105 was expected", set.Len())
+	}
+
+	for _, testCase := range []struct {
+		URL              string
+		Contains, Parent bool
+		LongestMatch     string
+	}{
+		{"google.com", true, true, "google.com"},
+		{"keep.google.com", false, true, "google.com"},
+		{"ads.google.com", true, true, "ads.google.com"},
+		{"eu.ads.google.com", false, true, "ads.google.com"},
+		{"evil-google.com", false, false, ""},
+		{"com", false, false, ""},
+		{"tracker.example.com", true, true, "tracker.example.com"},
+		{"example.com", false, false, ""},
+		{"xn--n3h.com", true, true, "☃.com"},
+		{"google.co.uk", false, true, "co.uk"},
+		{"host0.example.org", true, true, "host0.example.org"},
+		{"www.host57.example.org", false, true, "host57.example.org"},
+		{"host99.example.org", true, true, "host99.example.org"},
+		{"host100.example.org", false, false, ""},
+		{"", false, false, ""},
+	} {
+		if result := set.Contains(testCase.URL); result != testCase.Contains {
+			t.Errorf("Url (%q) returned %v for DomainSet.Contains(), but %v was expected", testCase.URL, result, testCase.Contains)
+		}
+		if result := set.ContainsOrParent(testCase.URL); result != testCase.Parent {
+			t.Errorf("Url (%q) returned %v for DomainSet.ContainsOrParent(), but %v was expected", testCase.URL, result, testCase.Parent)
+		}
+		if result, _ := set.LongestMatch(testCase.URL); result != testCase.LongestMatch {
+			t.Errorf("Url (%q) returned %q for DomainSet.LongestMatch(), but %q was expected", testCase.URL, result, testCase.LongestMatch)
+		}
+	}
+
+	// Adding after lookups keeps the set sorted
+	set.Add("a.example.org")
+	if !set.Contains("a.example.org") || !set.Contains("host50.example.org") || set.Len() != 106 {
+		t.Errorf("DomainSet lost keys after adding to packed set")
+	}
+}
+
+// TestDomainSetInterleaved tests DomainSet with additions in between lookups
+func TestDomainSetInterleaved(t *testing.T) {
+	set := &DomainSet{}
+	for i := 0; i < 20000; i++ {
+		host := "host" + strconv.Itoa(i) + ".example.org"
+		set.Add(host)
+		if !set.Contains(host) || set.Len() != i+1 {
+			t.Fatalf("Url (%q) returned %v for DomainSet.Contains() and %d for DomainSet.Len() after adding it, but true and %d was expected", host, set.Contains(host), set.Len(), i+1)
+		}
+	}
+	for i := 0; i < 20000; i += 997 {
+		if host := "www.host" + strconv.Itoa(i) + ".example.org"; !set.ContainsOrParent(host) {
+			t.Errorf("Url (%q) returned false for DomainSet.ContainsOrParent(), but true was expected", host)
+		}
+	}
+}
+
+// TestDomainSetParser tests DomainSet normalizing hosts by a Parser
+func TestDomainSetParser(t *testing.T) {
+	set, err := NewParser(WithStrict(true)).ReadDomainSet(strings.NewReader("example.com\n-example.com\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if set.Len() != 1 || !set.Contains("example.com") {
+		t.Errorf("DomainSet of strict Parser holds %d hosts, but only example.com was expected", set.Len())
+	}
+}
+
+// TestDomainSetBinary tests DomainSet binary marshaling
+func TestDomainSetBinary(t *testing.T) {
+	set := &DomainSet{}
+	for i := 0; i < 1000; i++ {
+		set.Add("host" + strconv.Itoa(i) + ".example.org")
+	}
+	data, err := set.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := &DomainSet{}
+	if err := loaded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Len() != 1000 || !loaded.Contains("host999.example.org") || !loaded.ContainsOrParent("www.host0.example.org") || loaded.Contains("example.org") {
+		t.Errorf("DomainSet.UnmarshalBinary() did not restore the set")
+	}
+
+	// Block offsets are stored at the end, 4 bytes each
+	blocks := len(data) - 4*(1000/domainSetBlockSize+1)
+	movedBlock := append([]byte(nil), data...)
+	binary.LittleEndian.PutUint32(movedBlock[blocks+4:], binary.LittleEndian.Uint32(movedBlock[blocks+4:])+3)
+	movedFirstBlock := append([]byte(nil), data...)
+	binary.LittleEndian.PutUint32(movedFirstBlock[blocks:], 3)
+
+	// Block count overflowing the size of the offsets
+	overflow := append([]byte("DSET\x01\x00\x00"), make([]byte, binary.MaxVarintLen64)...)
+	overflow = append(overflow[:7+binary.PutUvarint(overflow[7:], 1<<62+1)], 0, 0, 0, 0)
+
+	for _, corrupted := range [][]byte{nil, []byte("DSET"), data[:len(data)-1], data[:len(data)/2], append([]byte("XSET"), data[4:]...), movedBlock, movedFirstBlock, overflow} {
+		if err := (&DomainSet{}).UnmarshalBinary(corrupted); err == nil {
+			t.Errorf("DomainSet.UnmarshalBinary() accepted corrupted data of length %d", len(corrupted))
+		}
+	}
+}
+
+// BenchmarkDomainSet benchmarks DomainSet.ContainsOrParent() function
+func BenchmarkDomainSet(b *testing.B) {
+	set := &DomainSet{}
+	for i := 0; i < 100000; i++ {
+		set.Add("host" + strconv.Itoa(i) + ".example.com")
+	}
+	set.Len()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		set.ContainsOrParent("https://beta.gama.host99999.example.com?test=true")
+	}
+}
+
+// BenchmarkDomainSetAdd benchmarks DomainSet.Add() followed by DomainSet.Contains()
+func BenchmarkDomainSetAdd(b *testing.B) {
+	set := &DomainSet{}
+	for i := 0; i < b.N; i++ {
+		host := "host" + strconv.Itoa(i) + ".example.com"
+		set.Add(host)
+		set.Contains(host)
+	}
+}
@@ -0,0 +1,218 @@
+package domainutil
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// maxLocalPartLength is the maximum length of the local part of an address in octets (RFC 5321)
+	maxLocalPartLength = 64
+	// maxEmailLength is the maximum length of an address in octets, the path is limited to 256 octets including angle brackets (RFC 5321)
+	maxEmailLength = 254
+)
+
+// Email is a parsed e-mail address.
+type Email struct {
+	LocalPart         string // Local part as written, including quotes of a quoted local part
+	Domain            string // Normalized domain (or address literal such as [192.0.2.1])
+	RegistrableDomain string // Registrable domain of the domain, empty if it has none
+	Suffix            string // Public suffix of the domain, empty if it has no registrable domain
+	SMTPUTF8          bool   // Local part is not ASCII, so delivery requires SMTPUTF8 (RFC 6531)
+}
+
+// EmailError describes why an e-mail address failed validation.
+type EmailError struct {
+	Address string // Address as passed to ParseEmail
+	Reason  string // Reason of the failure
+}
+
+// Error implements the error interface.
+func (e *EmailError) Error() string {
+	return fmt.Sprintf("domainutil: invalid e-mail address %q: %s", e.Address, e.Reason)
+}
+
+// emailProvider describes how a mailbox provider treats local parts
+type emailProvider struct {
+	ignoreDots   bool   // Dots in the local part are ignored
+	tagSeparator string // Separator of the tag of subaddresses (user+tag), empty if not supported
+	domain       string // Canonical domain of the provider, empty if it is the domain itself
+}
+
+// emailProviders holds known mailbox providers by domain, their local parts are case-insensitive
+var emailProviders = map[string]emailProvider{
+	"gmail.com":      {ignoreDots: true, tagSeparator: "+"},
+	"googlemail.com": {ignoreDots: true, tagSeparator: "+", domain: "gmail.com"},
+	"outlook.com":    {tagSeparator: "+"},
+	"hotmail.com":    {tagSeparator: "+"},
+	"live.com":       {tagSeparator: "+"},
+	"icloud.com":     {tagSeparator: "+"},
+	"me.com":         {tagSeparator: "+"},
+	"mac.com":        {tagSeparator: "+"},
+	"fastmail.com":   {tagSeparator: "+"},
+	"protonmail.com": {tagSeparator: "+"},
+	"proton.me":      {tagSeparator: "+"},
+	"pm.me":          {tagSeparator: "+"},
+	"yandex.ru":      {tagSeparator: "+"},
+	"yahoo.com":      {tagSeparator: "-"},
+}
+
+// ParseEmail parses & validates e-mail address (addr-spec of RFC 5321, with UTF-8 allowed by RFC 6531).
+// The local part may be a dot-atom or a quoted string, the domain may be a host name (IDN included) or an address literal.
+func ParseEmail(addr string) (Email, error) {
+	return std().ParseEmail(addr)
+}
+
+// ParseEmail parses & validates e-mail address, see the ParseEmail function.
+func (p *Parser) ParseEmail(addr string) (Email, error) {
+	fail := func(reason string) (Email, error) {
+		return Email{}, &EmailError{Address: addr, Reason: reason}
+	}
+	if len(addr) > maxEmailLength {
+		return fail(fmt.Sprintf("longer than %d octets", maxEmailLength))
+	}
+	if !utf8.ValidString(addr) {
+		return fail("not valid UTF-8")
+	}
+
+	// Quoted local parts may contain @, the domain may not
+	at := strings.LastIndex(addr, "@")
+	if at == -1 {
+		return fail("missing @")
+	}
+	local, domain := addr[:at], addr[at+1:]
+	if reason := validateLocalPart(local); reason != "" {
+		return fail(reason)
+	}
+	email := Email{LocalPart: local, SMTPUTF8: !isASCII(local)}
+
+	// Address literals have no registrable domain
+	if strings.HasPrefix(domain, "[") {
+		if !strings.HasSuffix(domain, "]") || !isAddressLiteral(domain[1:len(domain)-1]) {
+			return fail("invalid address literal " + domain)
+		}
+		email.Domain = strings.ToLower(domain)
+		return email, nil
+	}
+
+	if domain == "" || strings.HasSuffix(domain, ".") {
+		return fail("invalid domain " + domain)
+	}
+	if err := p.Validate(domain); err != nil {
+		return fail(strings.TrimPrefix(err.Error(), "domainutil: "))
+	}
+	parts, _ := p.Parse(domain)
+	email.Domain, email.RegistrableDomain, email.Suffix = parts.Host, parts.Domain, parts.Suffix
+	return email, nil
+}
+
+// String returns the address.
+func (e Email) String() string {
+	return e.LocalPart + "@" + e.Domain
+}
+
+// Normalized returns the address in the form used to detect duplicates.
+// Local parts of known providers are lower cased and their subaddress tags (user+tag) are removed,
+// Gmail addresses also lose dots of their local parts. Other addresses are returned as they are,
+// because their local parts may be case-sensitive.
+func (e Email) Normalized() Email {
+	return std().NormalizeEmail(e)
+}
+
+// NormalizeEmail returns the address in the form used to detect duplicates, see the Email.Normalized method.
+// Use it for addresses parsed by the Parser, so the domain of a replaced provider follows its options.
+func (p *Parser) NormalizeEmail(e Email) Email {
+	provider, known := emailProviders[strings.ToLower(e.Domain)]
+	if !known || strings.HasPrefix(e.LocalPart, "\"") {
+		return e
+	}
+
+	local := strings.ToLower(e.LocalPart)
+	if provider.tagSeparator != "" {
+		if index := strings.Index(local, provider.tagSeparator); index > 0 {
+			local = local[:index]
+		}
+	}
+	if provider.ignoreDots {
+		local = strings.Replace(local, ".", "", -1)
+	}
+	e.LocalPart = local
+	if provider.domain != "" {
+		e.Domain = provider.domain
+		e.RegistrableDomain, e.Suffix = p.Domain(provider.domain), p.DomainSuffix(provider.domain)
+	}
+	return e
+}
+
+// validateLocalPart returns reason why the local part is invalid, empty if it is valid.
+func validateLocalPart(local string) string {
+	switch {
+	case local == "":
+		return "empty local part"
+	case len(local) > maxLocalPartLength:
+		return fmt.Sprintf("local part longer than %d octets", maxLocalPartLength)
+	case strings.HasPrefix(local, "\""):
+		return validateQuotedString(local)
+	}
+
+	// Dot-atom, atoms are separated by single dots
+	for _, atom := range strings.Split(local, ".") {
+		if atom == "" {
+			return "empty atom in local part"
+		}
+		for _, r := range atom {
+			if !isAtext(r) {
+				return fmt.Sprintf("invalid character %q in local part", r)
+			}
+		}
+	}
+	return ""
+}
+
+// validateQuotedString returns reason why the quoted local part is invalid, empty if it is valid.
+func validateQuotedString(local string) string {
+	if len(local) < 2 || !strings.HasSuffix(local, "\"") {
+		return "unterminated quoted local part"
+	}
+	content := local[1 : len(local)-1]
+	for i := 0; i < len(content); {
+		r, size := utf8.DecodeRuneInString(content[i:])
+		switch {
+		case r == '\\':
+			// Quoted pair escapes printable ASCII & space
+			if i+1 >= len(content) || content[i+1] < ' ' || content[i+1] > '~' {
+				return "invalid quoted pair in local part"
+			}
+			size = 2
+		case r == '"':
+			return "unescaped quote in local part"
+		case r < ' ' || r == 0x7f:
+			return fmt.Sprintf("invalid character %q in local part", r)
+		}
+		i += size
+	}
+	return ""
+}
+
+// isAtext reports whether r may be part of an atom (RFC 5322 atext, extended by UTF-8 in RFC 6531).
+func isAtext(r rune) bool {
+	switch {
+	case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', '0' <= r && r <= '9':
+		return true
+	case r >= utf8.RuneSelf:
+		return r != utf8.RuneError
+	}
+	return strings.ContainsRune("!#$%&'*+-/=?^_`{|}~", r)
+}
+
+// isAddressLiteral reports whether literal (without brackets) is an IPv4 or IPv6 address literal (RFC 5321).
+func isAddressLiteral(literal string) bool {
+	if strings.HasPrefix(strings.ToLower(literal), "ipv6:") {
+		address := literal[len("ipv6:"):]
+		return net.ParseIP(address) != nil && strings.Contains(address, ":")
+	}
+	ip := net.ParseIP(literal)
+	return ip != nil && ip.To4() != nil && !strings.Contains(literal, ":")
+}
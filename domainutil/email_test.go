@@ -0,0 +1,106 @@
+package domainutil
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func ExampleParseEmail() {
+	email, _ := ParseEmail("John.Doe+newsletter@mail.Example.co.uk")
+	fmt.Println(email.LocalPart, email.Domain, email.RegistrableDomain, email.Suffix)
+
+	gmail, _ := ParseEmail("John.Doe+newsletter@googlemail.com")
+	fmt.Println(gmail.Normalized())
+	// Output: John.Doe+newsletter mail.example.co.uk example.co.uk co.uk
+	// johndoe@gmail.com
+}
+
+// TestParseEmail tests ParseEmail() function
+func TestParseEmail(t *testing.T) {
+	for addr, expected := range map[string]Email{
+		"user@example.com":                {LocalPart: "user", Domain: "example.com", RegistrableDomain: "example.com", Suffix: "com"},
+		"first.last@sub.Example.CO.UK":    {LocalPart: "first.last", Domain: "sub.example.co.uk", RegistrableDomain: "example.co.uk", Suffix: "co.uk"},
+		"!#$%&'*+-/=?^_`{|}~@example.org": {LocalPart: "!#$%&'*+-/=?^_`{|}~", Domain: "example.org", RegistrableDomain: "example.org", Suffix: "org"},
+		`"john doe"@example.com`:          {LocalPart: `"john doe"`, Domain: "example.com", RegistrableDomain: "example.com", Suffix: "com"},
+		`"a@b\"c"@example.com`:            {LocalPart: `"a@b\"c"`, Domain: "example.com", RegistrableDomain: "example.com", Suffix: "com"},
+		"user@münchen.de":                 {LocalPart: "user", Domain: "münchen.de", RegistrableDomain: "münchen.de", Suffix: "de"},
+		"user@xn--mnchen-3ya.de":          {LocalPart: "user", Domain: "münchen.de", RegistrableDomain: "münchen.de", Suffix: "de"},
+		"用户@例子.广告":                        {LocalPart: "用户", Domain: "例子.广告", SMTPUTF8: true},
+		"δοκιμή@παράδειγμα.ελ":            {LocalPart: "δοκιμή", Domain: "παράδειγμα.ελ", RegistrableDomain: "παράδειγμα.ελ", Suffix: "ελ", SMTPUTF8: true},
+		"user@localhost":                  {LocalPart: "user", Domain: "localhost"},
+		"user@[192.0.2.1]":                {LocalPart: "user", Domain: "[192.0.2.1]"},
+		"user@[IPv6:2001:db8::1]":         {LocalPart: "user", Domain: "[ipv6:2001:db8::1]"},
+	} {
+		email, err := ParseEmail(addr)
+		if err != nil || email != expected {
+			t.Errorf("Address (%q) returned %+v, %v for ParseEmail(), but %+v was expected", addr, email, err, expected)
+		}
+	}
+}
+
+// TestParseEmailErrors tests that invalid addresses are rejected
+func TestParseEmailErrors(t *testing.T) {
+	for _, addr := range []string{
+		"",
+		"user",
+		"@example.com",
+		"user@",
+		"user@example.com.",
+		".user@example.com",
+		"user.@example.com",
+		"us..er@example.com",
+		"us er@example.com",
+		"us(er)@example.com",
+		`"unterminated@example.com`,
+		`"bad"quote"@example.com`,
+		"user@-example.com",
+		"user@exa_mple.com",
+		"user@[300.0.0.1]",
+		"user@[IPv6:nonsense]",
+		"user@[IPv6:1.2.3.4]",
+		"user@[2001:db8::1]",
+		strings.Repeat("a", 65) + "@example.com",
+		"user@" + string([]byte{0xff}) + ".com",
+	} {
+		if email, err := ParseEmail(addr); err == nil {
+			t.Errorf("Address (%q) returned %+v for ParseEmail(), but error was expected", addr, email)
+		} else if _, ok := err.(*EmailError); !ok {
+			t.Errorf("Address (%q) returned %T for ParseEmail(), but *EmailError was expected", addr, err)
+		}
+	}
+}
+
+// TestEmailNormalized tests Normalized() method
+func TestEmailNormalized(t *testing.T) {
+	for addr, expected := range map[string]string{
+		"John.Doe+news@gmail.com":   "johndoe@gmail.com",
+		"j.o.h.n@googlemail.com":    "john@gmail.com",
+		"John.Doe+news@Outlook.com": "john.doe@outlook.com",
+		"john-spam@yahoo.com":       "john@yahoo.com",
+		"+tag@gmail.com":            "+tag@gmail.com",
+		"John.Doe+news@example.com": "John.Doe+news@example.com",
+		`"John.Doe+news"@gmail.com`: `"John.Doe+news"@gmail.com`,
+	} {
+		email, err := ParseEmail(addr)
+		if err != nil {
+			t.Errorf("Address (%q) returned error %v for ParseEmail()", addr, err)
+			continue
+		}
+		if normalized := email.Normalized().String(); normalized != expected {
+			t.Errorf("Address (%q) returned %q for Normalized(), but %q was expected", addr, normalized, expected)
+		}
+	}
+
+	if email, _ := ParseEmail("x@googlemail.com"); email.Normalized().RegistrableDomain != "gmail.com" {
+		t.Errorf("Address (%q) returned %q registrable domain for Normalized(), but %q was expected", "x@googlemail.com", email.Normalized().RegistrableDomain, "gmail.com")
+	}
+
+	parser := NewParser(WithASCII(true), WithPreserveCase(true))
+	if email, _ := parser.ParseEmail("John.Doe@münchen.de"); email.Domain != "xn--mnchen-3ya.de" {
+		t.Errorf("Address (%q) returned %q domain for Parser.ParseEmail(), but %q was expected", "John.Doe@münchen.de", email.Domain, "xn--mnchen-3ya.de")
+	}
+	if email, _ := parser.ParseEmail("John.Doe@GoogleMail.com"); parser.NormalizeEmail(email).String() != "johndoe@gmail.com" {
+		t.Errorf("Address (%q) returned %q for Parser.NormalizeEmail(), but %q was expected", "John.Doe@GoogleMail.com", parser.NormalizeEmail(email), "johndoe@gmail.com")
+	}
+}
@@ -0,0 +1,76 @@
+package domainutil
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Explanation describes how a host was split on its public suffix boundary.
+type Explanation struct {
+	Host   string        // Normalized host
+	Rule   *Rule         // Prevailing rule, nil if no rule matched
+	Suffix string        // Public suffix as returned by DomainSuffix
+	Domain string        // Registrable domain as returned by Domain
+	Steps  []ExplainStep // Steps of the walk trough the list, starting with the last label (a label may match both itself and *)
+}
+
+// ExplainStep is a single step of the walk trough the suffix list.
+type ExplainStep struct {
+	Label   string // Label of the host
+	Match   string // Label of the list matching it (the label itself, * or !label), empty if the walk ended
+	Rule    *Rule  // Rule ending with the matched label, nil if there is none
+	Ignored bool   // Rule was ignored, because it is private and only ICANN rules are used
+}
+
+// Explain describes how provided url is split by Domain and DomainSuffix.
+func Explain(url string) Explanation {
+	return std().Explain(url)
+}
+
+// Explain describes how provided url is split by the Domain and DomainSuffix methods.
+func (p *Parser) Explain(url string) Explanation {
+	raw, _ := splitHostPort(authority(url))
+	parts := p.splitHost(raw)
+	explanation := Explanation{Host: parts.host, Suffix: parts.suffix, Domain: parts.domain()}
+	if parts.host == "" {
+		return explanation
+	}
+
+	lookup := labelsOf(strings.ToLower(p.cleanHost(raw)))
+	_, explanation.Rule = p.options.list.match(lookup, p.options.icannOnly, p.options.defaultRule, &explanation.Steps)
+	return explanation
+}
+
+// String returns description of the rule, e.g. *.ck (wildcard, ICANN, line 6789).
+func (r *Rule) String() string {
+	if r.Line == 0 {
+		return fmt.Sprintf("%s (%s, default)", r.Text, r.Kind)
+	}
+	return fmt.Sprintf("%s (%s, %s, line %d)", r.Text, r.Kind, r.Section, r.Line)
+}
+
+// String returns multi-line human readable form of the explanation.
+func (e Explanation) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "host:   %s\n", e.Host)
+	if e.Rule != nil {
+		fmt.Fprintf(&b, "rule:   %s\n", e.Rule)
+	} else {
+		b.WriteString("rule:   none\n")
+	}
+	fmt.Fprintf(&b, "suffix: %s\n", e.Suffix)
+	fmt.Fprintf(&b, "domain: %s\n", e.Domain)
+	for _, step := range e.Steps {
+		switch {
+		case step.Match == "":
+			fmt.Fprintf(&b, "  %s: no match\n", step.Label)
+		case step.Rule == nil:
+			fmt.Fprintf(&b, "  %s: matched %s, no rule\n", step.Label, step.Match)
+		case step.Ignored:
+			fmt.Fprintf(&b, "  %s: matched %s, rule %s ignored\n", step.Label, step.Match, step.Rule)
+		default:
+			fmt.Fprintf(&b, "  %s: matched %s, rule %s\n", step.Label, step.Match, step.Rule)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,95 @@
+package domainutil
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func ExampleExplain() {
+	list, _ := ReadSuffixList(strings.NewReader("// ck\n*.ck\n!www.ck\n"))
+	fmt.Print(NewParser(WithSuffixList(list)).Explain("https://www.ck/about"))
+	// Output: host:   www.ck
+	// rule:   !www.ck (exception, ICANN, line 3)
+	// suffix: ck
+	// domain: www.ck
+	//   ck: matched ck, no rule
+	//   www: matched !www, rule !www.ck (exception, ICANN, line 3)
+}
+
+// TestExplain tests Explain() function
+func TestExplain(t *testing.T) {
+	list, err := ReadSuffixList(strings.NewReader(`// ===BEGIN ICANN DOMAINS===
+io
+*.ck
+!www.ck
+// ===END ICANN DOMAINS===
+// ===BEGIN PRIVATE DOMAINS===
+github.io
+// ===END PRIVATE DOMAINS===
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		url     string
+		options []Option
+		rule    string
+		suffix  string
+		domain  string
+		steps   []ExplainStep
+	}{
+		{"a.b.github.io", nil, "github.io (normal, PRIVATE, line 7)", "github.io", "b.github.io", []ExplainStep{
+			{Label: "io", Match: "io"},
+			{Label: "github", Match: "github"},
+			{Label: "b"},
+		}},
+		{"a.b.github.io", []Option{WithICANNOnly(true)}, "io (normal, ICANN, line 2)", "io", "github.io", []ExplainStep{
+			{Label: "io", Match: "io"},
+			{Label: "github", Match: "github", Ignored: true},
+			{Label: "b"},
+		}},
+		{"foo.bar.ck", nil, "*.ck (wildcard, ICANN, line 3)", "bar.ck", "foo.bar.ck", []ExplainStep{
+			{Label: "ck", Match: "ck"},
+			{Label: "bar", Match: "*"},
+			{Label: "foo"},
+		}},
+		{"example.unknown", []Option{WithDefaultRule(true)}, "* (wildcard, default)", "unknown", "example.unknown", []ExplainStep{
+			{Label: "unknown"},
+		}},
+		{"example.unknown", nil, "", "", "", []ExplainStep{
+			{Label: "unknown"},
+		}},
+		{"", nil, "", "", "", nil},
+	} {
+		explanation := NewParser(append(test.options, WithSuffixList(list))...).Explain(test.url)
+		rule := ""
+		if explanation.Rule != nil {
+			rule = explanation.Rule.String()
+		}
+		if rule != test.rule || explanation.Suffix != test.suffix || explanation.Domain != test.domain {
+			t.Errorf("Url (%q) returned rule %q, suffix %q & domain %q for Explain(), but %q, %q & %q were expected", test.url, rule, explanation.Suffix, explanation.Domain, test.rule, test.suffix, test.domain)
+		}
+		if len(explanation.Steps) != len(test.steps) {
+			t.Errorf("Url (%q) returned %d steps for Explain(), but %d were expected", test.url, len(explanation.Steps), len(test.steps))
+			continue
+		}
+		for i, step := range explanation.Steps {
+			if step.Label != test.steps[i].Label || step.Match != test.steps[i].Match || step.Ignored != test.steps[i].Ignored {
+				t.Errorf("Url (%q) returned %+v for step %d of Explain(), but %+v was expected", test.url, step, i, test.steps[i])
+			}
+		}
+	}
+}
+
+// TestExplainBuiltin tests that the built-in list keeps rule provenance
+func TestExplainBuiltin(t *testing.T) {
+	explanation := Explain("keep.google.co.uk")
+	if explanation.Rule == nil || explanation.Rule.Text != "co.uk" || explanation.Rule.Section != ICANNSection || explanation.Rule.Line == 0 {
+		t.Errorf("Url (%q) returned rule %v for Explain(), but co.uk from the ICANN section was expected", "keep.google.co.uk", explanation.Rule)
+	}
+	if line := strings.Split(publicSuffixList, "\n")[explanation.Rule.Line-1]; line != "co.uk" {
+		t.Errorf("Rule co.uk points to line %q of the list", line)
+	}
+}
@@ -0,0 +1,323 @@
+package domainutil
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxExtractToken is the longest run of text without delimiters inspected at once,
+// longer runs (such as base64 blobs) are cut into pieces of this length
+const maxExtractToken = 64 * 1024
+
+// defangs holds defanged forms of URLs & hosts with their originals, matched case-insensitively
+var defangs = []struct {
+	defanged, original string
+}{
+	{"[.]", "."},
+	{"(.)", "."},
+	{"{.}", "."},
+	{"[dot]", "."},
+	{"(dot)", "."},
+	{"{dot}", "."},
+	{"\\.", "."},
+	{"[:]", ":"},
+	{"[://]", "://"},
+	{"hxxp", "http"},
+	{"fxp://", "ftp://"},
+}
+
+// HostMatch is a host found in text by HostScanner.
+type HostMatch struct {
+	Host   string // Normalized & refanged host
+	Domain string // Registrable domain of the host
+	URL    string // Refanged URL, if the host was found in one
+	Text   string // Matched text as found in the input (the whole URL for URLs)
+	Offset int64  // Byte offset of the text in the input
+}
+
+// HostScanner finds hosts and URLs in free text, see ExtractHosts.
+// The interface follows bufio.Scanner: call Scan until it returns false, then check Err.
+type HostScanner struct {
+	parser   *Parser
+	scanner  *bufio.Scanner
+	consumed int64 // Bytes of the input consumed by the split function
+	start    int64 // Offset of the current token
+	pending  []HostMatch
+	match    HostMatch
+}
+
+// ExtractHosts returns scanner of hosts and URLs found in text read from r (e-mails, chat logs, documents).
+// Defanged forms such as example[.]com and hxxp:// are refanged. Only hosts with a known public suffix are reported,
+// so file names such as notes.txt are skipped. The input is streamed, so it may be arbitrarily large.
+func ExtractHosts(r io.Reader) *HostScanner {
+	return std().ExtractHosts(r)
+}
+
+// ExtractHosts returns scanner of hosts and URLs found in text read from r, see the ExtractHosts function.
+func (p *Parser) ExtractHosts(r io.Reader) *HostScanner {
+	s := &HostScanner{parser: p, scanner: bufio.NewScanner(r)}
+	s.scanner.Buffer(make([]byte, 64*1024), 2*maxExtractToken)
+	s.scanner.Split(s.split)
+	return s
+}
+
+// Scan advances to the next host, it returns false at the end of the input or on error.
+func (s *HostScanner) Scan() bool {
+	for len(s.pending) == 0 {
+		if !s.scanner.Scan() {
+			return false
+		}
+		s.pending = s.extract(s.scanner.Bytes(), s.pending[:0])
+	}
+	s.match, s.pending = s.pending[0], s.pending[1:]
+	return true
+}
+
+// Match returns the host found by the last call to Scan.
+func (s *HostScanner) Match() HostMatch {
+	return s.match
+}
+
+// Err returns the first error of reading the input.
+func (s *HostScanner) Err() error {
+	return s.scanner.Err()
+}
+
+// split is bufio.SplitFunc returning runs of text between delimiters (white space & quotes) while tracking their offsets.
+func (s *HostScanner) split(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	start := 0
+	for start < len(data) && isExtractDelimiter(data[start]) {
+		start++
+	}
+	end := start
+	for end < len(data) && !isExtractDelimiter(data[end]) {
+		end++
+	}
+
+	switch {
+	case end < len(data) || atEOF && end > start:
+		advance = end // Complete token
+	case end-start >= maxExtractToken:
+		end = start + maxExtractToken
+		advance = end
+	default:
+		s.consumed += int64(start) // Request more data
+		return start, nil, nil
+	}
+	s.start = s.consumed + int64(start)
+	s.consumed += int64(advance)
+	return advance, data[start:end], nil
+}
+
+// isExtractDelimiter reports whether b never occurs in hosts nor in defanged URLs.
+func isExtractDelimiter(b byte) bool {
+	return b <= ' ' || b == '"' || b == '\'' || b == '<' || b == '>' || b == '`' || b == '|' || b == 0x7f
+}
+
+// extract appends matches found in the token to matches.
+func (s *HostScanner) extract(token []byte, matches []HostMatch) []HostMatch {
+	// Hosts need a dot, maybe defanged
+	if bytes.IndexByte(token, '.') == -1 && !containsFold(token, "dot") {
+		return matches
+	}
+	text, origins := refang(token)
+
+	// URLs take the rest of the token, hosts are looked up before them
+	end := len(text)
+	scheme := bytes.Index(text, []byte("://"))
+	if scheme > -1 {
+		start := scheme
+		for start > 0 && isSchemeByte(text[start-1]) {
+			start--
+		}
+		if start < scheme {
+			end = start
+		}
+	}
+
+	// Find runs of host characters
+	for i := 0; i < end; {
+		r, size := utf8.DecodeRune(text[i:end])
+		if !isHostRune(r) {
+			i += size
+			continue
+		}
+		runStart := i
+		for i < end {
+			if r, size = utf8.DecodeRune(text[i:end]); !isHostRune(r) {
+				break
+			}
+			i += size
+		}
+		if match, found := s.host(token, text, origins, runStart, i); found {
+			matches = append(matches, match)
+		}
+	}
+
+	if end < len(text) {
+		if match, found := s.url(token, text, origins, end); found {
+			matches = append(matches, match)
+		}
+	}
+	return matches
+}
+
+// host returns match of the run of host characters text[start:end], if its suffix is known.
+func (s *HostScanner) host(token, text []byte, origins []int, start, end int) (HostMatch, bool) {
+	// Trim dots & hyphens around the host (end of a sentence, list of hosts)
+	for start < end && (text[start] == '.' || text[start] == '-' || text[start] == '_') {
+		start++
+	}
+	for end > start && (text[end-1] == '.' || text[end-1] == '-' || text[end-1] == '_') {
+		end--
+	}
+	if end-start > maxHostLength || bytes.IndexByte(text[start:end], '.') == -1 || !s.knownTLD(text[start:end]) {
+		return HostMatch{}, false
+	}
+
+	parts := s.parser.splitHost(string(text[start:end]))
+	if parts.suffix == "" {
+		return HostMatch{}, false
+	}
+	originStart, originEnd := origin(token, origins, start, end)
+	return HostMatch{
+		Host:   parts.host,
+		Domain: parts.domain(),
+		Text:   string(token[originStart:originEnd]),
+		Offset: s.start + int64(originStart),
+	}, true
+}
+
+// knownTLD quickly rejects hosts whose last label is not listed, before the host is split.
+func (s *HostScanner) knownTLD(host []byte) bool {
+	tld := host[bytes.LastIndexByte(host, '.')+1:]
+	if s.parser.options.defaultRule || len(tld) > 63 || !isASCII(string(tld)) || bytes.HasPrefix(tld, []byte("xn--")) {
+		return true // Left to the full lookup
+	}
+	var lower [63]byte
+	for i, b := range tld {
+		if 'A' <= b && b <= 'Z' {
+			b += 'a' - 'A'
+		}
+		lower[i] = b
+	}
+	_, found := s.parser.options.list.root.children[string(lower[:len(tld)])]
+	return found
+}
+
+// url returns match of the URL starting at text[start:], if the suffix of its host is known.
+func (s *HostScanner) url(token, text []byte, origins []int, start int) (HostMatch, bool) {
+	// Trim punctuation following the URL
+	end := len(text)
+	for end > start && bytes.IndexByte([]byte(".,;:!?)]}"), text[end-1]) > -1 {
+		end--
+	}
+
+	url := string(text[start:end])
+	parts := s.parser.split(url)
+	if parts.suffix == "" {
+		return HostMatch{}, false
+	}
+	originStart, originEnd := origin(token, origins, start, end)
+	return HostMatch{
+		Host:   parts.host,
+		Domain: parts.domain(),
+		URL:    url,
+		Text:   string(token[originStart:originEnd]),
+		Offset: s.start + int64(originStart),
+	}, true
+}
+
+// refang replaces defanged forms in the token.
+// It returns the refanged text and offsets in the token of every byte of the text, nil if the token was not changed.
+func refang(token []byte) ([]byte, []int) {
+	if !hasDefang(token) {
+		return token, nil
+	}
+
+	text, origins := make([]byte, 0, len(token)), make([]int, 0, len(token))
+	for i := 0; i < len(token); {
+		replaced := false
+		for _, defang := range defangs {
+			if len(token)-i >= len(defang.defanged) && equalFold(token[i:i+len(defang.defanged)], defang.defanged) {
+				for j := 0; j < len(defang.original); j++ {
+					text, origins = append(text, defang.original[j]), append(origins, i)
+				}
+				i += len(defang.defanged)
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			text, origins = append(text, token[i]), append(origins, i)
+			i++
+		}
+	}
+	return text, origins
+}
+
+// hasDefang reports whether the token may contain defanged forms (brackets, escaped dots, hxxp or fxp).
+func hasDefang(token []byte) bool {
+	for i, b := range token {
+		switch b {
+		case '[', '(', '{', '\\':
+			return true
+		case 'x', 'X':
+			if i > 0 && i+1 < len(token) && (token[i-1]|0x20 == 'h' && token[i+1]|0x20 == 'x' || token[i-1]|0x20 == 'f' && token[i+1]|0x20 == 'p') {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// origin returns range of the token refanged into text[start:end].
+func origin(token []byte, origins []int, start, end int) (int, int) {
+	if origins == nil {
+		return start, end
+	}
+	if end < len(origins) {
+		return origins[start], origins[end]
+	}
+	return origins[start], len(token)
+}
+
+// isHostRune reports whether r may be part of a host.
+func isHostRune(r rune) bool {
+	if r < utf8.RuneSelf {
+		return 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' || r == '-' || r == '.' || r == '_'
+	}
+	return r != utf8.RuneError && (unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r))
+}
+
+// isSchemeByte reports whether b may be part of URL scheme.
+func isSchemeByte(b byte) bool {
+	return 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z' || '0' <= b && b <= '9' || b == '+' || b == '-' || b == '.'
+}
+
+// containsFold reports whether ASCII lower cased substr is in b, ignoring case.
+func containsFold(b []byte, substr string) bool {
+	for i := 0; i+len(substr) <= len(b); i++ {
+		if equalFold(b[i:i+len(substr)], substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// equalFold reports whether b equals ASCII lower cased s, ignoring case.
+func equalFold(b []byte, s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := b[i]
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		if c != s[i] {
+			return false
+		}
+	}
+	return true
+}
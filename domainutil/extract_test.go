@@ -0,0 +1,115 @@
+package domainutil
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func ExampleExtractHosts() {
+	scanner := ExtractHosts(strings.NewReader("Payload from hxxps://evil[.]example[.]com/x.exe, see notes.txt or mail admin@Example.org."))
+	for scanner.Scan() {
+		match := scanner.Match()
+		fmt.Println(match.Offset, match.Host, match.URL)
+	}
+	// Output: 13 evil.example.com https://evil.example.com/x.exe
+	// 77 example.org
+}
+
+// TestExtractHosts tests ExtractHosts() function
+func TestExtractHosts(t *testing.T) {
+	for _, test := range []struct {
+		text     string
+		expected []HostMatch
+	}{
+		{"visit www.google.co.uk today", []HostMatch{
+			{Host: "www.google.co.uk", Domain: "google.co.uk", Text: "www.google.co.uk", Offset: 6},
+		}},
+		{"example(.)com, test{dot}example[DOT]net and foo\\.bar\\.org", []HostMatch{
+			{Host: "example.com", Domain: "example.com", Text: "example(.)com", Offset: 0},
+			{Host: "test.example.net", Domain: "example.net", Text: "test{dot}example[DOT]net", Offset: 15},
+			{Host: "foo.bar.org", Domain: "bar.org", Text: "foo\\.bar\\.org", Offset: 44},
+		}},
+		{"<a href=\"hXXp[:]//bad.example.com:8080/path?q=1\">here</a>.", []HostMatch{
+			{Host: "bad.example.com", Domain: "example.com", URL: "http://bad.example.com:8080/path?q=1", Text: "hXXp[:]//bad.example.com:8080/path?q=1", Offset: 9},
+		}},
+		{"(see https://www.example.com/a).", []HostMatch{
+			{Host: "www.example.com", Domain: "example.com", URL: "https://www.example.com/a", Text: "https://www.example.com/a", Offset: 5},
+		}},
+		{"report.pdf notes.txt index.html 1.2.3.4 v1.0 co.uk", nil},
+		{"münchen.de and xn--n3h.com", []HostMatch{
+			{Host: "münchen.de", Domain: "münchen.de", Text: "münchen.de", Offset: 0},
+			{Host: "☃.com", Domain: "☃.com", Text: "xn--n3h.com", Offset: 17},
+		}},
+		{"", nil},
+	} {
+		var matches []HostMatch
+		scanner := ExtractHosts(strings.NewReader(test.text))
+		for scanner.Scan() {
+			matches = append(matches, scanner.Match())
+		}
+		if err := scanner.Err(); err != nil {
+			t.Errorf("Text (%q) returned error %v for ExtractHosts()", test.text, err)
+		}
+		if !reflect.DeepEqual(matches, test.expected) {
+			t.Errorf("Text (%q) returned %+v for ExtractHosts(), but %+v was expected", test.text, matches, test.expected)
+		}
+		for _, match := range matches {
+			if text := test.text[match.Offset : match.Offset+int64(len(match.Text))]; text != match.Text {
+				t.Errorf("Text (%q) returned offset %d of %q, but %q is there", test.text, match.Offset, match.Text, text)
+			}
+		}
+	}
+}
+
+// TestExtractHostsOffsets tests offsets across reads of the input & cut long tokens
+func TestExtractHostsOffsets(t *testing.T) {
+	text := strings.Repeat("lorem ipsum dolor ", 10000) + "www.example.com " + strings.Repeat("x", 3*maxExtractToken) + " example.org"
+	scanner := ExtractHosts(&shortReader{strings.NewReader(text)})
+
+	var offsets []int64
+	for scanner.Scan() {
+		offsets = append(offsets, scanner.Match().Offset)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+	expected := []int64{int64(strings.Index(text, "www.example.com")), int64(strings.Index(text, "example.org"))}
+	if !reflect.DeepEqual(offsets, expected) {
+		t.Errorf("ExtractHosts() returned offsets %v, but %v were expected", offsets, expected)
+	}
+}
+
+// shortReader reads at most 1000 bytes at a time
+type shortReader struct {
+	r io.Reader
+}
+
+func (r *shortReader) Read(p []byte) (int, error) {
+	if len(p) > 1000 {
+		p = p[:1000]
+	}
+	return r.r.Read(p)
+}
+
+func BenchmarkExtractHosts(b *testing.B) {
+	text := strings.Repeat("Lorem ipsum dolor sit amet, see https://www.example.com/path and mail admin@example.org. ", 1000)
+	b.SetBytes(int64(len(text)))
+	for i := 0; i < b.N; i++ {
+		scanner := ExtractHosts(strings.NewReader(text))
+		for scanner.Scan() {
+		}
+	}
+}
+
+func BenchmarkExtractHostsProse(b *testing.B) {
+	text := strings.Repeat("Lorem ipsum dolor sit amet, consectetur adipiscing elit, e.g. sed do eiusmod tempor (see notes.txt). ", 1000)
+	b.SetBytes(int64(len(text)))
+	for i := 0; i < b.N; i++ {
+		scanner := ExtractHosts(strings.NewReader(text))
+		for scanner.Scan() {
+		}
+	}
+}
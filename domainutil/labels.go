@@ -0,0 +1,93 @@
+package domainutil
+
+import "strings"
+
+// LabelRole is a role of a label within a host
+type LabelRole int
+
+const (
+	// SubdomainLabel is a label of the subdomain (www in www.example.co.uk)
+	SubdomainLabel LabelRole = iota
+	// SLDLabel is the second-level domain label (example in www.example.co.uk)
+	SLDLabel
+	// SuffixLabel is a label of the public suffix (co & uk in www.example.co.uk)
+	SuffixLabel
+)
+
+// String returns name of the role.
+func (r LabelRole) String() string {
+	switch r {
+	case SubdomainLabel:
+		return "subdomain"
+	case SLDLabel:
+		return "sld"
+	case SuffixLabel:
+		return "suffix"
+	}
+	return "unknown"
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (r LabelRole) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// Label is a single label of a host with its role.
+type Label struct {
+	Text string    // Label itself
+	Role LabelRole // Role of the label
+}
+
+// EffectiveTLDPlusN returns public suffix of provided url with n labels in front of it.
+// For example n = 0 returns the suffix, n = 1 the registrable domain and n = 2 groups team.corp.example.com under corp.example.com.
+// If the host has fewer labels, this function returns empty string.
+func EffectiveTLDPlusN(url string, n int) string {
+	return std().EffectiveTLDPlusN(url, n)
+}
+
+// Labels returns labels of the host of provided url from the left with their roles.
+// The Nth label from the right is labels[len(labels)-1-N]. If no domain is found in provided url, this function returns nil.
+func Labels(url string) []Label {
+	return std().Labels(url)
+}
+
+// SubdomainDepth returns the number of subdomain levels of provided url (2 for a.b.example.com).
+// If no domain is found in provided url, this function returns zero.
+func SubdomainDepth(url string) int {
+	return std().SubdomainDepth(url)
+}
+
+// EffectiveTLDPlusN returns public suffix of provided url with n labels in front of it, see the EffectiveTLDPlusN function.
+func (p *Parser) EffectiveTLDPlusN(url string, n int) string {
+	parts := p.SplitDomain(url)
+	if n < 0 || n >= len(parts) {
+		return ""
+	}
+	return strings.Join(parts[len(parts)-1-n:], ".")
+}
+
+// Labels returns labels of the host of provided url with their roles, see the Labels function.
+func (p *Parser) Labels(url string) []Label {
+	parts := p.SplitDomain(url)
+	if len(parts) < 2 {
+		return nil
+	}
+
+	var labels []Label
+	for _, text := range parts[:len(parts)-2] {
+		labels = append(labels, Label{Text: text, Role: SubdomainLabel})
+	}
+	labels = append(labels, Label{Text: parts[len(parts)-2], Role: SLDLabel})
+	for _, text := range strings.Split(parts[len(parts)-1], ".") {
+		labels = append(labels, Label{Text: text, Role: SuffixLabel})
+	}
+	return labels
+}
+
+// SubdomainDepth returns the number of subdomain levels of provided url, see the SubdomainDepth function.
+func (p *Parser) SubdomainDepth(url string) int {
+	if parts := p.SplitDomain(url); len(parts) > 2 {
+		return len(parts) - 2
+	}
+	return 0
+}
@@ -0,0 +1,79 @@
+package domainutil
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func ExampleEffectiveTLDPlusN() {
+	fmt.Println(EffectiveTLDPlusN("https://team.corp.example.com/wiki", 2))
+	fmt.Println(SubdomainDepth("https://team.corp.example.com/wiki"))
+	// Output: corp.example.com
+	// 2
+}
+
+// TestEffectiveTLDPlusN tests EffectiveTLDPlusN() function
+func TestEffectiveTLDPlusN(t *testing.T) {
+	for _, test := range []struct {
+		url      string
+		n        int
+		expected string
+	}{
+		{"team.corp.example.com", 0, "com"},
+		{"team.corp.example.com", 1, "example.com"},
+		{"team.corp.example.com", 2, "corp.example.com"},
+		{"team.corp.example.com", 3, "team.corp.example.com"},
+		{"team.corp.example.com", 4, ""},
+		{"team.corp.example.com", -1, ""},
+		{"http://a.b.google.co.uk/path", 0, "co.uk"},
+		{"http://a.b.google.co.uk/path", 2, "b.google.co.uk"},
+		{"example.unknowntld", 0, ""},
+		{"", 1, ""},
+	} {
+		if result := EffectiveTLDPlusN(test.url, test.n); result != test.expected {
+			t.Errorf("Url (%q) returned %q for EffectiveTLDPlusN(%d), but %q was expected", test.url, result, test.n, test.expected)
+		}
+	}
+}
+
+// TestLabels tests Labels() function
+func TestLabels(t *testing.T) {
+	for url, expected := range map[string][]Label{
+		"www.example.co.uk": {
+			{"www", SubdomainLabel},
+			{"example", SLDLabel},
+			{"co", SuffixLabel},
+			{"uk", SuffixLabel},
+		},
+		"https://a.b.example.com:8080/": {
+			{"a", SubdomainLabel},
+			{"b", SubdomainLabel},
+			{"example", SLDLabel},
+			{"com", SuffixLabel},
+		},
+		"example.com":        {{"example", SLDLabel}, {"com", SuffixLabel}},
+		"co.uk":              nil,
+		"example.unknowntld": nil,
+	} {
+		if result := Labels(url); !reflect.DeepEqual(result, expected) {
+			t.Errorf("Url (%q) returned %v for Labels(), but %v was expected", url, result, expected)
+		}
+	}
+}
+
+// TestSubdomainDepth tests SubdomainDepth() function
+func TestSubdomainDepth(t *testing.T) {
+	for url, expected := range map[string]int{
+		"example.com":            0,
+		"www.example.com":        1,
+		"a.b.example.co.uk":      2,
+		"x.y.z.bobesa.github.io": 3,
+		"www.example.unknowntld": 0,
+		"":                       0,
+	} {
+		if result := SubdomainDepth(url); result != expected {
+			t.Errorf("Url (%q) returned %d for SubdomainDepth(), but %d was expected", url, result, expected)
+		}
+	}
+}
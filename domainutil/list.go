@@ -0,0 +1,247 @@
+package domainutil
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// SuffixList is a parsed public suffix list.
+type SuffixList struct {
+	root *node
+}
+
+// node is a single label of the suffix tree.
+// Wildcard rules are stored under the * label, exception rules under the label prefixed with !.
+type node struct {
+	children map[string]*node
+	rule     *Rule // Rule ending with this label, nil if the label is only part of longer rules
+}
+
+// RuleKind is a kind of a suffix list rule
+type RuleKind int
+
+const (
+	// NormalRule is a plain rule such as co.uk
+	NormalRule RuleKind = iota
+	// WildcardRule is a rule matching any label in place of the asterisk such as *.ck
+	WildcardRule
+	// ExceptionRule is a rule exempting a host from a wildcard rule such as !www.ck
+	ExceptionRule
+)
+
+// String returns name of the kind.
+func (k RuleKind) String() string {
+	switch k {
+	case NormalRule:
+		return "normal"
+	case WildcardRule:
+		return "wildcard"
+	case ExceptionRule:
+		return "exception"
+	}
+	return "unknown"
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (k RuleKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// Section is a section of the suffix list
+type Section int
+
+const (
+	// ICANNSection holds suffixes delegated by ICANN & registries
+	ICANNSection Section = iota
+	// PrivateSection holds suffixes submitted by their owners (e.g. github.io)
+	PrivateSection
+)
+
+// String returns name of the section.
+func (s Section) String() string {
+	switch s {
+	case ICANNSection:
+		return "ICANN"
+	case PrivateSection:
+		return "PRIVATE"
+	}
+	return "unknown"
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Section) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Rule is a single rule of the suffix list.
+type Rule struct {
+	Text    string   // Rule as listed, with labels in unicode (e.g. *.ck)
+	Kind    RuleKind // Kind of the rule
+	Section Section  // Section of the list the rule is listed in
+	Line    int      // Line of the rule in the list starting with 1, zero for the implicit * rule
+	Owner   *Owner   // Organization owning a private rule, nil if not known
+}
+
+// defaultSuffixRule is the implicit * rule applied when no other rule matches
+var defaultSuffixRule = &Rule{Text: "*", Kind: WildcardRule}
+
+// builtinSuffixList is the list generated from publicsuffix.org
+var builtinSuffixList = mustReadSuffixList(publicSuffixList)
+
+// mustReadSuffixList parses the list and panics on failure.
+func mustReadSuffixList(list string) *SuffixList {
+	l, err := ReadSuffixList(strings.NewReader(list))
+	if err != nil {
+		panic(err)
+	}
+	return l
+}
+
+// ReadSuffixList reads a list in the format of https://publicsuffix.org/list/public_suffix_list.dat.
+// Rules listed between the BEGIN and END PRIVATE DOMAINS markers are marked as private.
+func ReadSuffixList(r io.Reader) (*SuffixList, error) {
+	root, section, rules := &node{}, ICANNSection, 0
+	var owner *Owner
+	blockStart := true // Next comment starts a new block of rules
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		// Track sections & owners of private rules, skip comments & empty lines
+		text := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(text, "// ===BEGIN PRIVATE DOMAINS"):
+			section, owner, blockStart = PrivateSection, nil, true
+			continue
+		case strings.HasPrefix(text, "// ===END PRIVATE DOMAINS"):
+			section, owner, blockStart = ICANNSection, nil, true
+			continue
+		case text == "":
+			blockStart = true
+			continue
+		case strings.HasPrefix(text, "//"):
+			if section == PrivateSection {
+				owner = readOwnerComment(owner, blockStart, strings.TrimSpace(text[2:]))
+			}
+			blockStart = false
+			continue
+		}
+		blockStart = false
+
+		// Rule ends with the first white space
+		if index := strings.IndexAny(text, " \t"); index > -1 {
+			text = text[:index]
+		}
+		text = strings.ToLower(text)
+		if strings.Index(text, "xn--") != -1 {
+			var err error
+			if text, err = idna.ToUnicode(text); err != nil {
+				return nil, err
+			}
+		}
+
+		// Add labels to the tree in reverse
+		current := root
+		labels := strings.Split(text, ".")
+		for i := len(labels) - 1; i >= 0; i-- {
+			current = current.child(labels[i])
+		}
+		current.rule = &Rule{Text: text, Kind: ruleKind(text), Section: section, Line: line, Owner: owner}
+		rules++
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if rules == 0 {
+		return nil, errors.New("domainutil: suffix list has no rules")
+	}
+	return &SuffixList{root: root}, nil
+}
+
+// ruleKind returns kind of the rule text.
+func ruleKind(text string) RuleKind {
+	switch {
+	case strings.HasPrefix(text, "!"):
+		return ExceptionRule
+	case strings.HasPrefix(text, "*."):
+		return WildcardRule
+	}
+	return NormalRule
+}
+
+// child returns child node of the label, creating it if needed.
+func (n *node) child(label string) *node {
+	if n.children == nil {
+		n.children = map[string]*node{}
+	}
+	child, found := n.children[label]
+	if !found {
+		child = &node{}
+		n.children[label] = child
+	}
+	return child
+}
+
+// SetSuffixList replaces the list used by the package functions.
+// Passing nil restores the built-in list.
+func SetSuffixList(list *SuffixList) {
+	updateDefaultParser(WithSuffixList(list))
+}
+
+// suffixLength returns the number of trailing labels forming the public suffix according to the prevailing rule.
+// Wildcard (*.ck) and exception (!www.ck) rules are honored, private rules are skipped if icannOnly is set.
+// If no rule matches, this function returns zero or one if defaultRule is set (the * rule).
+func (l *SuffixList) suffixLength(labels []string, icannOnly, defaultRule bool) int {
+	length, _ := l.match(labels, icannOnly, defaultRule, nil)
+	return length
+}
+
+// match returns the number of trailing labels forming the public suffix and the prevailing rule (nil if none).
+// If steps is not nil, every step of the walk is appended to it.
+func (l *SuffixList) match(labels []string, icannOnly, defaultRule bool, steps *[]ExplainStep) (int, *Rule) {
+	length, candidates := 0, []*node{l.root}
+	var matched *Rule
+
+	// Cycle trough labels in reverse, following the label and the wildcard as both can lead to the longest rule
+	for i := len(labels) - 1; i >= 0 && len(candidates) > 0; i-- {
+		// Exception rule ends the suffix before this label
+		for _, current := range candidates {
+			if exception, found := current.children["!"+labels[i]]; found && exception.rule != nil && !(icannOnly && exception.rule.Section == PrivateSection) {
+				if steps != nil {
+					*steps = append(*steps, ExplainStep{Label: labels[i], Match: "!" + labels[i], Rule: exception.rule})
+				}
+				return len(labels) - 1 - i, exception.rule
+			}
+		}
+
+		// Check for rule, wildcard rule matches any label
+		var next []*node
+		for _, current := range candidates {
+			for _, key := range [...]string{labels[i], "*"} {
+				child, found := current.children[key]
+				if !found || key == "*" && labels[i] == "*" {
+					continue
+				}
+				next = append(next, child)
+				ignored := child.rule != nil && icannOnly && child.rule.Section == PrivateSection
+				if child.rule != nil && !ignored {
+					length, matched = len(labels)-i, child.rule
+				}
+				if steps != nil {
+					*steps = append(*steps, ExplainStep{Label: labels[i], Match: key, Rule: child.rule, Ignored: ignored})
+				}
+			}
+		}
+		if len(next) == 0 && steps != nil {
+			*steps = append(*steps, ExplainStep{Label: labels[i]})
+		}
+		candidates = next
+	}
+
+	if length == 0 && defaultRule && labels[len(labels)-1] != "" {
+		return 1, defaultSuffixRule
+	}
+	return length, matched
+}
@@ -0,0 +1,76 @@
+package domainutil
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func ExampleSetSuffixList() {
+	list, _ := ReadSuffixList(strings.NewReader("// intranet\ncorp\n*.dev.corp\n"))
+	SetSuffixList(list)
+	fmt.Printf("%q\n", Domain("wiki.intranet.corp"))
+	fmt.Printf("%q\n", Domain("wiki.team.dev.corp"))
+	SetSuffixList(nil)
+	fmt.Printf("%q\n", Domain("wiki.intranet.corp"))
+	// Output: "intranet.corp"
+	// "wiki.team.dev.corp"
+	// ""
+}
+
+// TestReadSuffixList tests ReadSuffixList() function
+func TestReadSuffixList(t *testing.T) {
+	list, err := ReadSuffixList(strings.NewReader(`// ===BEGIN ICANN DOMAINS===
+
+// uk
+uk
+co.uk
+
+// ck
+*.ck
+!www.ck
+
+// x
+x
+*.x
+a.b.x
+
+XN--N3H // comment after rule
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetSuffixList(list)
+	defer SetSuffixList(nil)
+
+	for url, expected := range map[string]string{
+		"google.co.uk":      "google.co.uk",
+		"keep.google.uk":    "google.uk",
+		"foo.bar.ck":        "foo.bar.ck",
+		"www.ck":            "www.ck",
+		"snow.xn--n3h":      "snow.☃",
+		"google.com":        "",
+		"google.comment":    "",
+		"keep.google.after": "",
+		"c.b.x":             "c.b.x",
+		"d.a.b.x":           "d.a.b.x",
+	} {
+		if result := Domain(url); result != expected {
+			t.Errorf("Url (%q) returned %q for Domain() with custom list, but %q was expected", url, result, expected)
+		}
+	}
+
+	// Wildcard rule applies even when a longer rule shares the label
+	for url, expected := range map[string]string{
+		"c.b.x":   "b.x",
+		"d.a.b.x": "a.b.x",
+	} {
+		if result := DomainSuffix(url); result != expected {
+			t.Errorf("Url (%q) returned %q for DomainSuffix() with custom list, but %q was expected", url, result, expected)
+		}
+	}
+
+	if _, err := ReadSuffixList(strings.NewReader("// nothing but comments\n")); err == nil {
+		t.Errorf("ReadSuffixList() accepted list without rules")
+	}
+}
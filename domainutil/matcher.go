@@ -0,0 +1,117 @@
+package domainutil
+
+import (
+	"fmt"
+	"strings"
+)
+
+// matchFlags tells which hosts are matched by a pattern
+type matchFlags uint8
+
+const (
+	matchSelf       matchFlags = 1 << iota // Pattern matches its own host
+	matchSubdomains                        // Pattern matches subdomains of its host
+)
+
+// Matcher matches hosts against a compiled list of patterns.
+// Matcher is safe for concurrent use.
+type Matcher struct {
+	parser  *Parser
+	include map[string]matchFlags
+	exclude map[string]matchFlags
+}
+
+// NewMatcher compiles patterns into a Matcher.
+//
+// Supported patterns are:
+//
+//	example.com      example.com and all its subdomains
+//	.example.com     example.com and all its subdomains
+//	*.example.com    all subdomains of example.com, but not example.com itself
+//	=www.example.com www.example.com only
+//	!bad.example.com exception, any of the patterns above prefixed with ! excludes matching hosts
+//
+// Patterns are normalized the same way as hosts passed to the other functions of this package
+// and are matched on whole labels only, so example.com never matches evil-example.com.
+// Empty patterns and patterns starting with # are ignored.
+func NewMatcher(patterns []string) (*Matcher, error) {
+	return std().NewMatcher(patterns)
+}
+
+// NewMatcher compiles patterns into a Matcher, see the NewMatcher function.
+// Patterns and matched urls are normalized by the Parser.
+func (p *Parser) NewMatcher(patterns []string) (*Matcher, error) {
+	m := &Matcher{
+		parser:  p,
+		include: make(map[string]matchFlags, len(patterns)),
+		exclude: map[string]matchFlags{},
+	}
+	for _, pattern := range patterns {
+		if err := m.add(pattern); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// add compiles single pattern into the matcher.
+func (m *Matcher) add(pattern string) error {
+	p := strings.TrimSpace(pattern)
+	if p == "" || strings.HasPrefix(p, "#") {
+		return nil
+	}
+
+	// Exceptions go to separate set
+	rules := m.include
+	if strings.HasPrefix(p, "!") {
+		rules, p = m.exclude, p[1:]
+	}
+
+	// Resolve pattern type
+	var flags matchFlags
+	switch {
+	case strings.HasPrefix(p, "="):
+		flags, p = matchSelf, p[1:]
+	case strings.HasPrefix(p, "*."):
+		flags, p = matchSubdomains, p[2:]
+	case strings.HasPrefix(p, "."):
+		flags, p = matchSelf|matchSubdomains, p[1:]
+	default:
+		flags = matchSelf | matchSubdomains
+	}
+
+	host := m.parser.stripURLParts(p)
+	if host == "" || strings.ContainsAny(host, "*=!") || strings.HasPrefix(host, ".") {
+		return fmt.Errorf("domainutil: invalid pattern %q", pattern)
+	}
+	rules[host] |= flags
+	return nil
+}
+
+// Match reports whether the host of provided url matches any pattern and no exception.
+func (m *Matcher) Match(url string) bool {
+	host := m.parser.stripURLParts(url)
+	return host != "" && matchRules(m.include, host) && !matchRules(m.exclude, host)
+}
+
+// matchRules reports whether host or any of its parents matches the rules.
+func matchRules(rules map[string]matchFlags, host string) bool {
+	if len(rules) == 0 {
+		return false
+	}
+	if rules[host]&matchSelf != 0 {
+		return true
+	}
+
+	// Cycle trough parents
+	for parent := host; ; {
+		index := strings.Index(parent, ".")
+		if index == -1 {
+			return false
+		}
+		parent = parent[index+1:]
+		if rules[parent]&matchSubdomains != 0 {
+			return true
+		}
+	}
+}
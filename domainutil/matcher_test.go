@@ -0,0 +1,100 @@
+package domainutil
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+func ExampleMatcher() {
+	m, _ := NewMatcher([]string{"google.com", "!ads.google.com"})
+	fmt.Println(m.Match("https://keep.google.com/u/0/"))
+	fmt.Println(m.Match("https://ads.google.com/"))
+	fmt.Println(m.Match("https://evil-google.com/"))
+	// Output: true
+	// false
+	// false
+}
+
+// TestMatcher tests Matcher.Match() function
+func TestMatcher(t *testing.T) {
+	m, err := NewMatcher([]string{
+		"# comment",
+		"",
+		"google.com",
+		".amazon.co.uk",
+		"*.github.io",
+		"=www.example.com",
+		"!bad.google.com",
+		"!=private.github.io",
+		"HTTPS://Wikipedia.ORG/wiki",
+		"xn--n3h.com",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, testCase := range []struct {
+		URL      string
+		Expected bool
+	}{
+		{"google.com", true},
+		{"keep.google.com", true},
+		{"http://a.b.keep.google.com:8080/path", true},
+		{"GOOGLE.com.", true},
+		{"evil-google.com", false},
+		{"google.com.evil.com", false},
+		{"bad.google.com", false},
+		{"very.bad.google.com", false},
+		{"notbad.google.com", true},
+		{"amazon.co.uk", true},
+		{"www.amazon.co.uk", true},
+		{"co.uk", false},
+		{"github.io", false},
+		{"bobesa.github.io", true},
+		{"private.github.io", false},
+		{"www.private.github.io", true},
+		{"www.example.com", true},
+		{"example.com", false},
+		{"a.www.example.com", false},
+		{"wikipedia.org", true},
+		{"☃.com", true},
+		{"xn--n3h.com", true},
+		{"", false},
+	} {
+		if result := m.Match(testCase.URL); result != testCase.Expected {
+			t.Errorf("Url (%q) returned %v for Matcher.Match(), but %v was expected", testCase.URL, result, testCase.Expected)
+		}
+	}
+}
+
+// TestNewMatcherInvalid tests that NewMatcher() rejects invalid patterns
+func TestNewMatcherInvalid(t *testing.T) {
+	for _, pattern := range []string{"*", "!", "=", "*.", "a.*.com", "..com", "http://"} {
+		if _, err := NewMatcher([]string{pattern}); err == nil {
+			t.Errorf("Pattern (%q) was accepted by NewMatcher(), but error was expected", pattern)
+		}
+	}
+
+	// Strict parser rejects hosts failing validation
+	if _, err := NewParser(WithStrict(true)).NewMatcher([]string{"-example.com"}); err == nil {
+		t.Errorf("Pattern (%q) was accepted by strict Parser.NewMatcher(), but error was expected", "-example.com")
+	}
+}
+
+// BenchmarkMatcher benchmarks Matcher.Match() function with large list of patterns
+func BenchmarkMatcher(b *testing.B) {
+	patterns := make([]string, 50000)
+	for i := range patterns {
+		patterns[i] = "host" + strconv.Itoa(i) + ".example.com"
+	}
+	m, err := NewMatcher(patterns)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Match("https://beta.gama.host49999.example.com?test=true")
+	}
+}
@@ -0,0 +1,162 @@
+package domainutil
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// Name is a validated, normalized host name with its public suffix boundaries computed once.
+//
+// Name is an immutable value comparable with ==, its zero value is the empty name.
+// It can be stored as text (flags, configuration files, JSON) and in databases, where it is validated on the way in.
+type Name struct {
+	host        string
+	domainStart int // Index of the registrable domain in host, -1 if there is none
+	suffixStart int // Index of the public suffix in host, -1 if there is none
+}
+
+// ParseName parses & validates host of provided url (or the host itself).
+// The host is normalized (lower cased, in unicode, without the trailing dot), but it does not need to have a registrable domain.
+func ParseName(url string) (Name, error) {
+	return std().ParseName(url)
+}
+
+// ParseName parses & validates host of provided url (or the host itself), see the ParseName function.
+func (p *Parser) ParseName(url string) (Name, error) {
+	parts, err := p.Parse(url)
+	if err == ErrNoHost {
+		return Name{}, err
+	}
+	if err := p.Validate(parts.Host); err != nil {
+		return Name{}, err
+	}
+
+	name := Name{host: parts.Host, domainStart: -1, suffixStart: -1}
+	if parts.Domain != "" {
+		name.domainStart = len(parts.Host) - len(parts.Domain)
+		name.suffixStart = len(parts.Host) - len(parts.Suffix)
+	}
+	return name, nil
+}
+
+// MustParseName is like ParseName, but panics if the host is invalid.
+func MustParseName(url string) Name {
+	name, err := ParseName(url)
+	if err != nil {
+		panic(err)
+	}
+	return name
+}
+
+// IsZero reports whether the name is empty.
+func (n Name) IsZero() bool {
+	return n.host == ""
+}
+
+// String returns the host.
+func (n Name) String() string {
+	return n.host
+}
+
+// ASCII returns the host in ASCII (A-label) form.
+func (n Name) ASCII() string {
+	return toASCII(n.host)
+}
+
+// Domain returns registrable domain of the name, empty if it has none.
+func (n Name) Domain() string {
+	if n.domainStart == -1 {
+		return ""
+	}
+	return n.host[n.domainStart:]
+}
+
+// Suffix returns public suffix of the name, empty if it has no registrable domain.
+func (n Name) Suffix() string {
+	if n.suffixStart == -1 {
+		return ""
+	}
+	return n.host[n.suffixStart:]
+}
+
+// SLD returns second-level domain of the name, empty if it has no registrable domain.
+func (n Name) SLD() string {
+	if n.domainStart == -1 {
+		return ""
+	}
+	return n.host[n.domainStart : n.suffixStart-1]
+}
+
+// Subdomain returns subdomain of the name, empty if it has none.
+func (n Name) Subdomain() string {
+	if n.domainStart < 1 {
+		return ""
+	}
+	return n.host[:n.domainStart-1]
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (n Name) MarshalText() ([]byte, error) {
+	return []byte(n.host), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// Empty text gives the zero name, other text must be a valid host.
+func (n *Name) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*n = Name{}
+		return nil
+	}
+	name, err := ParseName(string(text))
+	if err != nil {
+		return err
+	}
+	*n = name
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n Name) MarshalJSON() ([]byte, error) {
+	return json.Marshal(n.host)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, null gives the zero name.
+func (n *Name) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*n = Name{}
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	return n.UnmarshalText([]byte(text))
+}
+
+// Value implements driver.Valuer, the zero name is stored as NULL.
+func (n Name) Value() (driver.Value, error) {
+	if n.IsZero() {
+		return nil, nil
+	}
+	return n.host, nil
+}
+
+// Scan implements sql.Scanner.
+func (n *Name) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*n = Name{}
+		return nil
+	case string:
+		return n.UnmarshalText([]byte(value))
+	case []byte:
+		return n.UnmarshalText(value)
+	}
+	return fmt.Errorf("domainutil: cannot scan %T into Name", src)
+}
+
+// Set implements flag.Value.
+func (n *Name) Set(value string) error {
+	return n.UnmarshalText([]byte(value))
+}
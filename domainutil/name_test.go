@@ -0,0 +1,125 @@
+package domainutil
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"testing"
+)
+
+func ExampleName() {
+	var config struct {
+		Host Name `json:"host"`
+	}
+	json.Unmarshal([]byte(`{"host": "WWW.Example.co.uk."}`), &config)
+	fmt.Println(config.Host, config.Host.Domain(), config.Host.Suffix())
+
+	_, err := ParseName("under_score.example.com")
+	fmt.Println(err != nil)
+	// Output: www.example.co.uk example.co.uk co.uk
+	// true
+}
+
+// TestParseName tests ParseName() function and accessors of Name
+func TestParseName(t *testing.T) {
+	for _, test := range []struct {
+		url                                  string
+		host, subdomain, sld, suffix, domain string
+	}{
+		{"https://a.b.Example.co.uk:8080/path", "a.b.example.co.uk", "a.b", "example", "co.uk", "example.co.uk"},
+		{"example.com", "example.com", "", "example", "com", "example.com"},
+		{"xn--n3h.com", "☃.com", "", "☃", "com", "☃.com"},
+		{"www.example.unknowntld", "www.example.unknowntld", "", "", "", ""},
+		{"co.uk", "co.uk", "", "", "", ""},
+	} {
+		name, err := ParseName(test.url)
+		if err != nil {
+			t.Errorf("Url (%q) returned error %v for ParseName()", test.url, err)
+			continue
+		}
+		if name.String() != test.host || name.Subdomain() != test.subdomain || name.SLD() != test.sld || name.Suffix() != test.suffix || name.Domain() != test.domain {
+			t.Errorf("Url (%q) returned %q (%q, %q, %q, %q) for ParseName(), but %q (%q, %q, %q, %q) was expected", test.url,
+				name, name.Subdomain(), name.SLD(), name.Suffix(), name.Domain(), test.host, test.subdomain, test.sld, test.suffix, test.domain)
+		}
+	}
+
+	for _, url := range []string{"", "http:///path", "under_score.example.com", "-example.com", "a..com"} {
+		if name, err := ParseName(url); err == nil {
+			t.Errorf("Url (%q) returned %q for ParseName(), but error was expected", url, name)
+		}
+	}
+
+	if MustParseName("WWW.example.com") != MustParseName("www.example.com.") {
+		t.Errorf("Names of equal hosts are not equal")
+	}
+	if name := MustParseName("☃.com").ASCII(); name != "xn--n3h.com" {
+		t.Errorf("Name (%q) returned %q for ASCII(), but %q was expected", "☃.com", name, "xn--n3h.com")
+	}
+	if name, _ := NewParser(WithICANNOnly(true)).ParseName("foo.blogspot.com"); name.Domain() != "blogspot.com" {
+		t.Errorf("Url (%q) returned %q for Parser.ParseName().Domain(), but %q was expected", "foo.blogspot.com", name.Domain(), "blogspot.com")
+	}
+}
+
+// TestNameJSON tests JSON & text marshaling of Name
+func TestNameJSON(t *testing.T) {
+	type record struct {
+		Host  Name
+		Other Name
+	}
+	data, err := json.Marshal(record{Host: MustParseName("keep.google.com")})
+	if err != nil || string(data) != `{"Host":"keep.google.com","Other":""}` {
+		t.Errorf("json.Marshal() returned %s, %v", data, err)
+	}
+
+	var decoded record
+	if err := json.Unmarshal([]byte(`{"Host":"keep.google.com","Other":null}`), &decoded); err != nil || decoded.Host != MustParseName("keep.google.com") || !decoded.Other.IsZero() {
+		t.Errorf("json.Unmarshal() returned %+v, %v", decoded, err)
+	}
+	if err := json.Unmarshal([]byte(`{"Host":"in valid"}`), &decoded); err == nil {
+		t.Errorf("json.Unmarshal() returned no error for invalid host")
+	}
+
+	var name Name
+	if err := name.UnmarshalText(nil); err != nil || !name.IsZero() {
+		t.Errorf("UnmarshalText() returned %q, %v for empty text, but zero name was expected", name, err)
+	}
+}
+
+// TestNameSQL tests Scan & Value of Name
+func TestNameSQL(t *testing.T) {
+	var name Name
+	for _, src := range []interface{}{"keep.google.com", []byte("KEEP.google.com")} {
+		if err := name.Scan(src); err != nil || name.Domain() != "google.com" {
+			t.Errorf("Scan(%v) returned %q, %v, but keep.google.com was expected", src, name, err)
+		}
+	}
+	if value, err := name.Value(); err != nil || value != "keep.google.com" {
+		t.Errorf("Value() returned %v, %v, but keep.google.com was expected", value, err)
+	}
+
+	if err := name.Scan(nil); err != nil || !name.IsZero() {
+		t.Errorf("Scan(nil) returned %q, %v, but zero name was expected", name, err)
+	}
+	if value, err := name.Value(); err != nil || value != nil {
+		t.Errorf("Value() returned %v, %v for zero name, but nil was expected", value, err)
+	}
+	if err := name.Scan(42); err == nil {
+		t.Errorf("Scan(42) returned no error")
+	}
+}
+
+// TestNameFlag tests Name as flag.Value
+func TestNameFlag(t *testing.T) {
+	var name Name
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.SetOutput(ioutil.Discard)
+	flags.Var(&name, "host", "host name")
+
+	if err := flags.Parse([]string{"-host", "https://www.example.com/"}); err != nil || name.String() != "www.example.com" {
+		t.Errorf("flag.Parse() returned %q, %v, but www.example.com was expected", name, err)
+	}
+	if err := flags.Parse([]string{"-host", "bad_host.com"}); err == nil {
+		t.Errorf("flag.Parse() returned no error for invalid host")
+	}
+}
@@ -0,0 +1,67 @@
+package domainutil
+
+import (
+	"errors"
+	"net"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// defaultPorts holds default ports of well known protocols
+var defaultPorts = map[string]string{
+	"ftp":   "21",
+	"http":  "80",
+	"https": "443",
+	"ws":    "80",
+	"wss":   "443",
+}
+
+// hostProfile maps hosts for lookup (case, width & compatibility mapping) while allowing underscores
+var hostProfile = idna.New(idna.MapForLookup(), idna.BidiRule(), idna.StrictDomainName(false))
+
+// NormalizeHost returns canonical form of the host from provided url.
+//
+// The host is percent-decoded, lower cased and IDNA-mapped to its unicode form,
+// the same form other functions of this package use for hosts.
+// The port is dropped if it is the default port of the url's protocol.
+// The trailing dot of absolute domain names (example.com.) is removed unless keepTrailingDot is set.
+func NormalizeHost(url string, keepTrailingDot bool) (string, error) {
+	return std().NormalizeHost(url, keepTrailingDot)
+}
+
+// NormalizeHost returns canonical form of the host from provided url, see the NormalizeHost function.
+// Invalid hosts are rejected if the Parser is strict.
+func (p *Parser) NormalizeHost(url string, keepTrailingDot bool) (string, error) {
+	host, port := splitHostPort(authority(url))
+	host, fqdn, err := p.normalizeHost(host)
+	if err != nil {
+		return "", err
+	}
+
+	// Check & drop the default port
+	for i := 0; i < len(port); i++ {
+		if port[i] < '0' || port[i] > '9' {
+			return "", errors.New("domainutil: invalid port " + port)
+		}
+	}
+	if port == defaultPorts[strings.ToLower(Protocol(url))] {
+		port = ""
+	}
+
+	if fqdn && keepTrailingDot {
+		host += "."
+	}
+	if port != "" {
+		host += ":" + port
+	}
+	return host, nil
+}
+
+// isIP reports whether host is an IP address, IPv6 addresses may be enclosed in square brackets.
+func isIP(host string) bool {
+	if strings.HasPrefix(host, "[") && strings.HasSuffix(host, "]") {
+		host = host[1 : len(host)-1]
+	}
+	return net.ParseIP(host) != nil
+}
@@ -0,0 +1,90 @@
+package domainutil
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// WebOrigin is the origin of an url as defined by the WHATWG URL standard.
+// The zero value is an opaque origin.
+type WebOrigin struct {
+	Scheme string // Lower cased protocol, empty for opaque origins
+	Host   string // Host in the form returned by the other functions of this package
+	Port   int    // Port, the default port of the protocol if the url has none
+}
+
+// Origin returns the origin of provided url.
+//
+// Urls with ftp, http, https, ws and wss protocols have tuple origins, blob urls have the origin of the url they wrap.
+// All the other urls (data:, file:, javascript:, urls without protocol, ...) have opaque origins.
+func Origin(url string) WebOrigin {
+	return std().Origin(url)
+}
+
+// SameOrigin reports whether both urls have the same origin.
+// Opaque origins are never the same as any other origin.
+func SameOrigin(a, b string) bool {
+	return std().SameOrigin(a, b)
+}
+
+// Origin returns the origin of provided url, see the Origin function.
+func (p *Parser) Origin(url string) WebOrigin {
+	// Blob urls inherit the origin of the inner url
+	if len(url) > 5 && strings.EqualFold(url[:5], "blob:") {
+		return p.Origin(url[5:])
+	}
+
+	scheme := strings.ToLower(Protocol(url))
+	defaultPort, found := defaultPorts[scheme]
+	if !found {
+		return WebOrigin{}
+	}
+
+	host := p.stripURLParts(url)
+	if host == "" {
+		return WebOrigin{}
+	}
+
+	_, port := splitHostPort(authority(url))
+	if port == "" {
+		port = defaultPort
+	}
+	number, err := strconv.Atoi(port)
+	if err != nil || number < 0 || number > 65535 {
+		return WebOrigin{}
+	}
+
+	return WebOrigin{Scheme: scheme, Host: host, Port: number}
+}
+
+// SameOrigin reports whether both urls have the same origin, see the SameOrigin function.
+func (p *Parser) SameOrigin(a, b string) bool {
+	origin := p.Origin(a)
+	return !origin.IsOpaque() && origin == p.Origin(b)
+}
+
+// IsOpaque reports whether the origin is opaque.
+func (o WebOrigin) IsOpaque() bool {
+	return o.Scheme == ""
+}
+
+// String returns the ASCII serialization of the origin (e.g. https://example.com:8443).
+// Opaque origins are serialized as "null", default ports are omitted.
+func (o WebOrigin) String() string {
+	if o.IsOpaque() {
+		return "null"
+	}
+
+	host, err := idna.ToASCII(o.Host)
+	if err != nil {
+		host = o.Host
+	}
+
+	origin := o.Scheme + "://" + host
+	if port := strconv.Itoa(o.Port); port != defaultPorts[o.Scheme] {
+		origin += ":" + port
+	}
+	return origin
+}
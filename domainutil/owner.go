@@ -0,0 +1,65 @@
+package domainutil
+
+import "strings"
+
+// Owner is an organization owning private suffixes, as named by the comments of the suffix list.
+type Owner struct {
+	Organization string // Name of the organization, e.g. Heroku
+	URL          string // Reference URL of the organization, empty if not listed
+	Submitter    string // Person who submitted the suffixes with their e-mail, empty if not listed
+}
+
+// SuffixOwner returns owner of the private suffix of provided url, e.g. GitHub, Inc. for bobesa.github.io.
+// If the suffix is not private or its owner is not listed, found is false.
+func SuffixOwner(url string) (owner Owner, found bool) {
+	return std().SuffixOwner(url)
+}
+
+// SuffixOwner returns owner of the private suffix of provided url, see the SuffixOwner function.
+func (p *Parser) SuffixOwner(url string) (owner Owner, found bool) {
+	raw, _ := splitHostPort(authority(url))
+	host := p.cleanHost(raw)
+	if host == "" {
+		return Owner{}, false
+	}
+	_, rule := p.options.list.match(labelsOf(strings.ToLower(host)), p.options.icannOnly, false, nil)
+	if rule == nil || rule.Owner == nil {
+		return Owner{}, false
+	}
+	return *rule.Owner, true
+}
+
+// readOwnerComment updates owner of the following rules by a comment of the private section.
+// The first comment of a block names the organization and often its URL (// Heroku : https://www.heroku.com/),
+// the following ones may name the submitter (// Submitted by Tom Maher <tmaher@heroku.com>).
+func readOwnerComment(owner *Owner, blockStart bool, comment string) *Owner {
+	if blockStart {
+		owner = &Owner{}
+		if index := urlIndex(comment); index > -1 {
+			owner.URL = strings.Fields(comment[index:])[0]
+			comment = comment[:index]
+		}
+		owner.Organization = strings.TrimSpace(strings.TrimRight(strings.TrimSpace(comment), ":"))
+		return owner
+	}
+	if owner == nil {
+		return nil
+	}
+
+	switch lower := strings.ToLower(comment); {
+	case strings.HasPrefix(lower, "submitted by ") && owner.Submitter == "":
+		owner.Submitter = strings.TrimSpace(comment[len("submitted by "):])
+	case (strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")) && owner.URL == "":
+		owner.URL = strings.Fields(comment)[0]
+	}
+	return owner
+}
+
+// urlIndex returns index of the first http(s) URL in the comment or -1.
+func urlIndex(comment string) int {
+	index := strings.Index(comment, "https://")
+	if plain := strings.Index(comment, "http://"); plain > -1 && (index == -1 || plain < index) {
+		index = plain
+	}
+	return index
+}
@@ -0,0 +1,61 @@
+package domainutil
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func ExampleSuffixOwner() {
+	owner, _ := SuffixOwner("https://myapp.herokuapp.com/login")
+	fmt.Println(owner.Organization, owner.URL)
+	// Output: Heroku https://www.heroku.com/
+}
+
+// TestSuffixOwner tests SuffixOwner() function
+func TestSuffixOwner(t *testing.T) {
+	list, err := ReadSuffixList(strings.NewReader(`// ===BEGIN ICANN DOMAINS===
+// io : http://www.nic.io/
+io
+// ===END ICANN DOMAINS===
+// ===BEGIN PRIVATE DOMAINS===
+// (Note: these are in alphabetical order by company name)
+
+// GitHub, Inc.
+// Submitted by Patrick Toomey <security@github.com>
+github.io
+
+// Heroku : https://www.heroku.com/
+// Submitted by Tom Maher <tmaher@heroku.com>
+herokuapp.com
+herokussl.com
+
+// Example
+// https://example.org/about
+*.example.io
+// ===END PRIVATE DOMAINS===
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parser := NewParser(WithSuffixList(list))
+	for url, expected := range map[string]Owner{
+		"bobesa.github.io":         {Organization: "GitHub, Inc.", Submitter: "Patrick Toomey <security@github.com>"},
+		"https://a.herokussl.com/": {Organization: "Heroku", URL: "https://www.heroku.com/", Submitter: "Tom Maher <tmaher@heroku.com>"},
+		"herokuapp.com":            {Organization: "Heroku", URL: "https://www.heroku.com/", Submitter: "Tom Maher <tmaher@heroku.com>"},
+		"a.b.example.io":           {Organization: "Example", URL: "https://example.org/about"},
+		"nic.io":                   {},
+		"example.com":              {},
+		"":                         {},
+	} {
+		owner, found := parser.SuffixOwner(url)
+		if owner != expected || found != (expected != Owner{}) {
+			t.Errorf("Url (%q) returned %+v, %v for SuffixOwner(), but %+v was expected", url, owner, found, expected)
+		}
+	}
+
+	if _, found := NewParser(WithSuffixList(list), WithICANNOnly(true)).SuffixOwner("bobesa.github.io"); found {
+		t.Errorf("Url (%q) returned owner for SuffixOwner() with ICANN rules only, but none was expected", "bobesa.github.io")
+	}
+}
@@ -0,0 +1,45 @@
+package domainutil
+
+import "strings"
+
+// ParentDomains returns ancestors of the host of provided url from the closest one, stopping at the registrable domain.
+// If includeSuffix is set, the labels of the public suffix follow (a.b.example.co.uk gives b.example.co.uk, example.co.uk, co.uk & uk).
+// If no domain is found in provided url, this function returns nil.
+func ParentDomains(url string, includeSuffix bool) []string {
+	return std().ParentDomains(url, includeSuffix)
+}
+
+// IsSubdomainOf reports whether host of child url is below host of parent url, comparing whole labels.
+// For example a.example.com is a subdomain of example.com, but not of ample.com or of itself.
+func IsSubdomainOf(child, parent string) bool {
+	return std().IsSubdomainOf(child, parent)
+}
+
+// ParentDomains returns ancestors of the host of provided url, see the ParentDomains function.
+func (p *Parser) ParentDomains(url string, includeSuffix bool) []string {
+	parts := p.split(url)
+	if parts.suffix == "" {
+		return nil
+	}
+
+	// Walk from the host to the registrable domain (or the TLD), dropping a label at a time
+	last := parts.domain()
+	if includeSuffix {
+		last = parts.suffix[strings.LastIndex(parts.suffix, ".")+1:]
+	}
+	var parents []string
+	for host := parts.host; host != last; {
+		host = host[strings.Index(host, ".")+1:]
+		parents = append(parents, host)
+	}
+	return parents
+}
+
+// IsSubdomainOf reports whether host of child url is below host of parent url, see the IsSubdomainOf function.
+func (p *Parser) IsSubdomainOf(child, parent string) bool {
+	childHost, parentHost := p.split(child).host, p.split(parent).host
+	if childHost == "" || parentHost == "" || isIP(childHost) || isIP(parentHost) {
+		return false
+	}
+	return strings.HasSuffix(strings.ToLower(childHost), "."+strings.ToLower(parentHost))
+}
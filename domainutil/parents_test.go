@@ -0,0 +1,62 @@
+package domainutil
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func ExampleParentDomains() {
+	fmt.Println(ParentDomains("https://a.b.example.co.uk/", false))
+	fmt.Println(ParentDomains("https://a.b.example.co.uk/", true))
+	// Output: [b.example.co.uk example.co.uk]
+	// [b.example.co.uk example.co.uk co.uk uk]
+}
+
+// TestParentDomains tests ParentDomains() function
+func TestParentDomains(t *testing.T) {
+	for _, test := range []struct {
+		url           string
+		includeSuffix bool
+		expected      []string
+	}{
+		{"a.b.example.co.uk", false, []string{"b.example.co.uk", "example.co.uk"}},
+		{"a.b.example.co.uk", true, []string{"b.example.co.uk", "example.co.uk", "co.uk", "uk"}},
+		{"example.co.uk", false, nil},
+		{"example.co.uk", true, []string{"co.uk", "uk"}},
+		{"www.example.com.", true, []string{"example.com", "com"}},
+		{"x.bobesa.github.io", false, []string{"bobesa.github.io"}},
+		{"x.bobesa.github.io", true, []string{"bobesa.github.io", "github.io", "io"}},
+		{"co.uk", true, nil},
+		{"www.example.unknowntld", true, nil},
+		{"", true, nil},
+	} {
+		if result := ParentDomains(test.url, test.includeSuffix); !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("Url (%q) returned %q for ParentDomains(%v), but %q was expected", test.url, result, test.includeSuffix, test.expected)
+		}
+	}
+}
+
+// TestIsSubdomainOf tests IsSubdomainOf() function
+func TestIsSubdomainOf(t *testing.T) {
+	for _, test := range []struct {
+		child    string
+		parent   string
+		expected bool
+	}{
+		{"a.example.com", "example.com", true},
+		{"https://a.b.Example.com/path", "http://EXAMPLE.com.", true},
+		{"example.com", "com", true},
+		{"example.com", "example.com", false},
+		{"a.example.com", "ample.com", false},
+		{"example.com", "a.example.com", false},
+		{"a.xn--n3h.com", "☃.com", true},
+		{"1.2.3.4", "3.4", false},
+		{"", "example.com", false},
+		{"example.com", "", false},
+	} {
+		if result := IsSubdomainOf(test.child, test.parent); result != test.expected {
+			t.Errorf("Urls (%q, %q) returned %v for IsSubdomainOf(), but %v was expected", test.child, test.parent, result, test.expected)
+		}
+	}
+}
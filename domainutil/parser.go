@@ -0,0 +1,344 @@
+package domainutil
+
+import (
+	"errors"
+	neturl "net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/net/idna"
+)
+
+// Parser extracts domain parts from urls according to its options.
+// The package functions use a default Parser, which can be adjusted by SetStrict, SetSuffixList, SetCache and SetRootZone.
+// Parser is safe for concurrent use.
+type Parser struct {
+	options  parserOptions
+	cache    *Cache
+	rootZone *RootZone
+}
+
+// parserOptions holds options affecting results of a Parser
+type parserOptions struct {
+	list         *SuffixList
+	icannOnly    bool
+	defaultRule  bool
+	ascii        bool
+	strict       bool
+	preserveCase bool
+}
+
+// Option configures a Parser.
+type Option func(*Parser)
+
+// WithSuffixList makes the Parser use provided list instead of the built-in one, nil selects the built-in list.
+func WithSuffixList(list *SuffixList) Option {
+	return func(p *Parser) {
+		if list == nil {
+			list = builtinSuffixList
+		}
+		p.options.list = list
+	}
+}
+
+// WithICANNOnly makes the Parser ignore rules from the PRIVATE DOMAINS section of the list (e.g. github.io).
+func WithICANNOnly(enabled bool) Option {
+	return func(p *Parser) { p.options.icannOnly = enabled }
+}
+
+// WithDefaultRule makes the Parser treat the last label as the suffix when no rule matches (the * rule of the list).
+// Without it, hosts with unknown TLD have no registrable domain.
+func WithDefaultRule(enabled bool) Option {
+	return func(p *Parser) { p.options.defaultRule = enabled }
+}
+
+// WithASCII makes the Parser return hosts in ASCII (A-label) form instead of unicode.
+func WithASCII(enabled bool) Option {
+	return func(p *Parser) { p.options.ascii = enabled }
+}
+
+// WithStrict makes the Parser treat hosts that fail Validate as not found.
+func WithStrict(enabled bool) Option {
+	return func(p *Parser) { p.options.strict = enabled }
+}
+
+// WithPreserveCase makes the Parser keep the case of ASCII hosts instead of lower casing them.
+// Internationalized hosts are always IDNA-mapped, which lower cases them.
+// The suffix list is still matched case-insensitively.
+func WithPreserveCase(enabled bool) Option {
+	return func(p *Parser) { p.options.preserveCase = enabled }
+}
+
+// WithCache makes the Parser cache split hosts in c, nil disables caching.
+// A cache may be shared by parsers, hosts are cached separately for parsers with different options.
+func WithCache(c *Cache) Option {
+	return func(p *Parser) { p.cache = c }
+}
+
+// NewParser creates Parser with provided options.
+// Without options the Parser behaves like the package functions by default.
+func NewParser(options ...Option) *Parser {
+	p := &Parser{options: parserOptions{list: builtinSuffixList}}
+	for _, option := range options {
+		option(p)
+	}
+	return p
+}
+
+var (
+	// defaultParser holds *Parser used by the package functions
+	defaultParser atomic.Value
+	// defaultParserMutex serializes updates of the default parser
+	defaultParserMutex sync.Mutex
+)
+
+func init() {
+	defaultParser.Store(NewParser())
+}
+
+// std returns the Parser used by the package functions.
+func std() *Parser {
+	return defaultParser.Load().(*Parser)
+}
+
+// updateDefaultParser replaces the default parser by its copy with the option applied.
+func updateDefaultParser(option Option) {
+	defaultParserMutex.Lock()
+	defer defaultParserMutex.Unlock()
+
+	p := *std()
+	option(&p)
+	defaultParser.Store(&p)
+}
+
+// HasSubdomain reports whether domain contains any subdomain.
+func (p *Parser) HasSubdomain(domain string) bool {
+	return p.split(domain).subdomain != ""
+}
+
+// Subdomain returns subdomain from provided url.
+// If subdomain is not found in provided url, this method returns empty string.
+func (p *Parser) Subdomain(url string) string {
+	return p.split(url).subdomain
+}
+
+// SplitDomain split domain into string array
+// for example, zh.wikipedia.org will split into {"zh", "wikipedia", "org"}
+func (p *Parser) SplitDomain(url string) []string {
+	parts := p.split(url)
+	if len(parts.suffix) == 0 {
+		return nil
+	}
+
+	if len(parts.sld) == 0 {
+		return []string{parts.suffix}
+	}
+
+	if len(parts.subdomain) == 0 {
+		return []string{parts.sld, parts.suffix}
+	}
+
+	array := strings.Split(parts.subdomain, ".")
+	res := append(array, parts.sld, parts.suffix)
+	return res
+}
+
+// DomainPrefix returns second-level domain from provided url.
+// If no SLD is found in provided url, this method returns empty string.
+func (p *Parser) DomainPrefix(url string) string {
+	return p.split(url).sld
+}
+
+// DomainSuffix returns domain suffix from provided url.
+// If no TLD is found in provided url, this method returns empty string.
+func (p *Parser) DomainSuffix(url string) string {
+	return p.split(url).suffix
+}
+
+// Domain returns top level domain from url string.
+// If no domain is found in provided url, this method returns empty string.
+// If no TLD is found in provided url, this method returns empty string.
+func (p *Parser) Domain(url string) string {
+	return p.split(url).domain()
+}
+
+// Parse splits provided url into all its parts in one pass.
+// If the url has no host or the host has no registrable domain, the parts found so far are returned with an error.
+func (p *Parser) Parse(url string) (Parts, error) {
+	host := p.split(url)
+	parts := Parts{
+		Protocol:  Protocol(url),
+		Username:  Username(url),
+		Password:  Password(url),
+		Host:      host.host,
+		Subdomain: host.subdomain,
+		SLD:       host.sld,
+		Suffix:    host.suffix,
+		Domain:    host.domain(),
+	}
+	if parts.Host == "" {
+		return parts, ErrNoHost
+	}
+	if parts.Suffix == "" {
+		return parts, ErrNoDomain
+	}
+	return parts, nil
+}
+
+// Protocol returns protocol from given url, see the Protocol function.
+func (p *Parser) Protocol(url string) string {
+	return Protocol(url)
+}
+
+// Username returns username from given url, see the Username function.
+func (p *Parser) Username(url string) string {
+	return Username(url)
+}
+
+// Password returns password from given url, see the Password function.
+func (p *Parser) Password(url string) string {
+	return Password(url)
+}
+
+// hostParts holds a host split on its public suffix boundary.
+type hostParts struct {
+	host      string // Whole host
+	subdomain string // Labels left of the second-level domain
+	sld       string // Second-level domain
+	suffix    string // Public suffix
+}
+
+// domain returns registrable domain (second-level domain & suffix).
+func (p hostParts) domain() string {
+	if p.suffix == "" {
+		return ""
+	}
+	return p.sld + "." + p.suffix
+}
+
+// split extracts host from provided url and splits it into its parts in one pass.
+// If the host has no registrable domain, only the host is set.
+func (p *Parser) split(url string) hostParts {
+	raw, _ := splitHostPort(authority(url))
+	if p.cache == nil {
+		return p.splitHost(raw)
+	}
+
+	// Results depend on the options, so they are part of the key
+	if parts, found := p.cache.get(raw, p.options); found {
+		return parts
+	}
+	parts := p.splitHost(raw)
+	p.cache.add(raw, parts, p.options)
+	return parts
+}
+
+// splitHost cleans raw host and splits it on the public suffix boundary.
+func (p *Parser) splitHost(raw string) hostParts {
+	host := p.cleanHost(raw)
+	parts := hostParts{host: host}
+
+	// Match the list on lower cased labels, but cut the host itself
+	labels, lookup := strings.Split(host, "."), labelsOf(host)
+	if p.options.preserveCase {
+		lookup = labelsOf(strings.ToLower(host))
+	}
+	length := p.options.list.suffixLength(lookup, p.options.icannOnly, p.options.defaultRule)
+	if length == 0 || length >= len(labels) {
+		return p.toOutput(parts)
+	}
+
+	// Cut the host on the label boundaries
+	sld := len(labels) - length - 1
+	parts.suffix = strings.Join(labels[sld+1:], ".")
+	parts.sld = labels[sld]
+	if sld > 0 {
+		parts.subdomain = strings.Join(labels[:sld], ".")
+	}
+	return p.toOutput(parts)
+}
+
+// toOutput converts parts to ASCII if requested.
+func (p *Parser) toOutput(parts hostParts) hostParts {
+	if !p.options.ascii {
+		return parts
+	}
+	parts.host = toASCII(parts.host)
+	parts.subdomain = toASCII(parts.subdomain)
+	parts.sld = toASCII(parts.sld)
+	parts.suffix = toASCII(parts.suffix)
+	return parts
+}
+
+// stripURLParts removes path, protocol, credentials, port & query from url and returns its cleaned host.
+func (p *Parser) stripURLParts(url string) string {
+	host, _ := splitHostPort(authority(url))
+	return p.cleanHost(host)
+}
+
+// cleanHost returns canonical form of host, see normalizeHost.
+// Hosts that cannot be normalized are turned into empty string.
+func (p *Parser) cleanHost(host string) string {
+	host, _, err := p.normalizeHost(host)
+	if err != nil {
+		return ""
+	}
+	return host
+}
+
+// normalizeHost percent-decodes host, strips its trailing dot and maps it to its unicode form.
+// Hosts with non-ASCII characters or A-labels are IDNA-mapped (case, width & compatibility mapping),
+// other hosts are lower cased unless the Parser preserves case. It also reports whether host had the trailing dot.
+// In strict mode invalid hosts are rejected.
+func (p *Parser) normalizeHost(host string) (string, bool, error) {
+	// Decode percent-encoded host
+	if strings.Index(host, "%") != -1 {
+		unescaped, err := neturl.PathUnescape(host)
+		if err != nil {
+			return "", false, err
+		}
+		host = unescaped
+	}
+
+	// Remember & strip the trailing dot of absolute domain names
+	fqdn := strings.HasSuffix(host, ".")
+	host = strings.TrimSuffix(host, ".")
+	if host == "" {
+		return "", fqdn, errors.New("domainutil: empty host")
+	}
+
+	// Map internationalized hosts, others are only lower cased
+	if !isASCII(host) || strings.Index(strings.ToLower(host), "xn--") != -1 {
+		var err error
+		if host, err = hostProfile.ToUnicode(host); err != nil {
+			return "", fqdn, err
+		}
+	} else if !p.options.preserveCase {
+		host = strings.ToLower(host)
+	}
+
+	// Reject invalid hosts in strict mode
+	if p.options.strict {
+		if err := p.Validate(host); err != nil {
+			return "", fqdn, err
+		}
+	}
+	return host, fqdn, nil
+}
+
+// labelsOf splits host into labels.
+func labelsOf(host string) []string {
+	return strings.Split(host, ".")
+}
+
+// toASCII converts host to its ASCII form, hosts that cannot be converted are kept as they are.
+func toASCII(host string) string {
+	if isASCII(host) {
+		return host
+	}
+	if ascii, err := idna.ToASCII(host); err == nil {
+		return ascii
+	}
+	return host
+}
@@ -0,0 +1,129 @@
+package domainutil
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func ExampleNewParser() {
+	parser := NewParser(WithICANNOnly(true), WithASCII(true))
+	fmt.Println(parser.Domain("https://bobesa.github.io/go-domain-util"))
+	fmt.Println(parser.Domain("http://www.☃.com"))
+	// Output: github.io
+	// xn--n3h.com
+}
+
+// TestParserOptions tests options of NewParser() function
+func TestParserOptions(t *testing.T) {
+	list, err := ReadSuffixList(strings.NewReader("corp\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		name     string
+		options  []Option
+		url      string
+		expected Parts
+	}{
+		{"default", nil, "https://a.b.github.io", Parts{Protocol: "https", Host: "a.b.github.io", Subdomain: "a", SLD: "b", Suffix: "github.io", Domain: "b.github.io"}},
+		{"icann only", []Option{WithICANNOnly(true)}, "https://a.b.github.io", Parts{Protocol: "https", Host: "a.b.github.io", Subdomain: "a.b", SLD: "github", Suffix: "io", Domain: "github.io"}},
+		{"unknown tld", nil, "www.example.unknowntld", Parts{Host: "www.example.unknowntld"}},
+		{"default rule", []Option{WithDefaultRule(true)}, "www.example.unknowntld", Parts{Host: "www.example.unknowntld", Subdomain: "www", SLD: "example", Suffix: "unknowntld", Domain: "example.unknowntld"}},
+		{"ascii", []Option{WithASCII(true)}, "www.☃.com", Parts{Host: "www.xn--n3h.com", Subdomain: "www", SLD: "xn--n3h", Suffix: "com", Domain: "xn--n3h.com"}},
+		{"unicode", nil, "www.xn--n3h.com", Parts{Host: "www.☃.com", Subdomain: "www", SLD: "☃", Suffix: "com", Domain: "☃.com"}},
+		{"strict", []Option{WithStrict(true)}, "under_score.example.com", Parts{}},
+		{"lenient", nil, "under_score.example.com", Parts{Host: "under_score.example.com", Subdomain: "under_score", SLD: "example", Suffix: "com", Domain: "example.com"}},
+		{"preserve case", []Option{WithPreserveCase(true)}, "Keep.Google.CO.UK", Parts{Host: "Keep.Google.CO.UK", Subdomain: "Keep", SLD: "Google", Suffix: "CO.UK", Domain: "Google.CO.UK"}},
+		{"suffix list", []Option{WithSuffixList(list)}, "wiki.intranet.corp", Parts{Host: "wiki.intranet.corp", Subdomain: "wiki", SLD: "intranet", Suffix: "corp", Domain: "intranet.corp"}},
+		{"builtin list", []Option{WithSuffixList(list), WithSuffixList(nil)}, "keep.google.com", Parts{Host: "keep.google.com", Subdomain: "keep", SLD: "google", Suffix: "com", Domain: "google.com"}},
+	} {
+		parts, _ := NewParser(test.options...).Parse(test.url)
+		if parts != test.expected {
+			t.Errorf("Parser (%s) returned %+v for Parse(%q), but %+v was expected", test.name, parts, test.url, test.expected)
+		}
+	}
+}
+
+// TestParserPolicy tests that site, cookie & certificate checks follow options of the Parser
+func TestParserPolicy(t *testing.T) {
+	list, err := ReadSuffixList(strings.NewReader("corp\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	all, icann, corp := NewParser(), NewParser(WithICANNOnly(true)), NewParser(WithSuffixList(list))
+
+	for _, test := range []struct {
+		name             string
+		result, expected bool
+	}{
+		{"SameSite", all.SameSite("https://a.github.io", "https://b.github.io"), false},
+		{"SameSite icann only", icann.SameSite("https://a.github.io", "https://b.github.io"), true},
+		{"SameRegistrableDomain", all.SameRegistrableDomain("a.intranet.corp", "b.intranet.corp"), false},
+		{"SameRegistrableDomain suffix list", corp.SameRegistrableDomain("a.intranet.corp", "b.intranet.corp"), true},
+		{"MatchCertificateName", all.MatchCertificateName("a.github.io", "*.github.io"), false},
+		{"MatchCertificateName icann only", icann.MatchCertificateName("a.github.io", "*.github.io"), true},
+		{"MatchCertificateName suffix list", corp.MatchCertificateName("a.intranet.corp", "*.intranet.corp"), true},
+		{"CookieDomainMatch", icann.CookieDomainMatch("a.github.io", ".github.io"), true},
+	} {
+		if test.result != test.expected {
+			t.Errorf("Parser (%s) returned %v, but %v was expected", test.name, test.result, test.expected)
+		}
+	}
+
+	if _, err := all.ValidateCookieDomain("a.github.io", "github.io"); err != ErrCookieDomainPublicSuffix {
+		t.Errorf("ValidateCookieDomain() returned %v, but %v was expected", err, ErrCookieDomainPublicSuffix)
+	}
+	if domain, err := icann.ValidateCookieDomain("a.github.io", "github.io"); domain != "github.io" || err != nil {
+		t.Errorf("ValidateCookieDomain() of ICANN only parser returned (%q, %v), but (%q, nil) was expected", domain, err, "github.io")
+	}
+	if host, err := NewParser(WithStrict(true)).NormalizeHost("under_score.example.com", false); err == nil {
+		t.Errorf("NormalizeHost() of strict parser returned %q, but error was expected", host)
+	}
+	if origin := corp.Origin("https://wiki.intranet.corp:8443/"); origin.String() != "https://wiki.intranet.corp:8443" {
+		t.Errorf("Origin() returned %q, but %q was expected", origin, "https://wiki.intranet.corp:8443")
+	}
+}
+
+// TestParserCache tests that parsers with different options sharing a cache do not see each other's results
+func TestParserCache(t *testing.T) {
+	cache := NewCache(1000)
+	icann := NewParser(WithICANNOnly(true), WithCache(cache))
+	all := NewParser(WithCache(cache))
+
+	for round := 0; round < 3; round++ {
+		if domain := icann.Domain("a.b.github.io"); domain != "github.io" {
+			t.Errorf("ICANN only parser returned %q for Domain(), but %q was expected", domain, "github.io")
+		}
+		if domain := all.Domain("a.b.github.io"); domain != "b.github.io" {
+			t.Errorf("Parser returned %q for Domain(), but %q was expected", domain, "b.github.io")
+		}
+	}
+}
+
+// TestSetStrictKeepsList tests that adjusting the default parser keeps its other options
+func TestSetStrictKeepsList(t *testing.T) {
+	list, err := ReadSuffixList(strings.NewReader("corp\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetSuffixList(list)
+	defer SetSuffixList(nil)
+	SetStrict(true)
+	defer SetStrict(false)
+
+	if domain := Domain("wiki.intranet.corp"); domain != "intranet.corp" {
+		t.Errorf("Url (%q) returned %q for Domain(), but %q was expected", "wiki.intranet.corp", domain, "intranet.corp")
+	}
+	if domain := Domain("wiki_.intranet.corp"); domain != "" {
+		t.Errorf("Url (%q) returned %q for Domain(), but %q was expected", "wiki_.intranet.corp", domain, "")
+	}
+}
+
+func BenchmarkParserDomain(b *testing.B) {
+	parser := NewParser(WithICANNOnly(true), WithDefaultRule(true))
+	for i := 0; i < b.N; i++ {
+		parser.Domain("https://keep.google.com/u/0/")
+	}
+}
@@ -0,0 +1,57 @@
+package domainutil
+
+import "strings"
+
+// SameRegistrableDomain reports whether both urls share the same registrable domain (as returned by Domain).
+// Urls without a registrable domain, such as IP addresses, public suffixes or hosts with unknown suffix,
+// never share it with anything.
+func SameRegistrableDomain(a, b string) bool {
+	return std().SameRegistrableDomain(a, b)
+}
+
+// SameSite reports whether both urls belong to the same site as defined by the HTML standard (schemeful same-site).
+// Urls are same-site if their protocols match and their hosts share the registrable domain.
+// IP addresses are same-site only with the very same address. Hosts with unknown suffix fall back
+// to the default rule of the public suffix list, which treats the last label as the suffix.
+func SameSite(a, b string) bool {
+	return std().SameSite(a, b)
+}
+
+// SameRegistrableDomain reports whether both urls share the same registrable domain, see the SameRegistrableDomain function.
+func (p *Parser) SameRegistrableDomain(a, b string) bool {
+	domainA := p.Domain(a)
+	return domainA != "" && domainA == p.Domain(b)
+}
+
+// SameSite reports whether both urls belong to the same site, see the SameSite function.
+func (p *Parser) SameSite(a, b string) bool {
+	if !strings.EqualFold(Protocol(a), Protocol(b)) {
+		return false
+	}
+	siteA := p.site(a)
+	return siteA != "" && siteA == p.site(b)
+}
+
+// site returns the host of url reduced to its registrable domain.
+// If the host has no registrable domain, the host itself is returned.
+func (p *Parser) site(url string) string {
+	host := p.stripURLParts(url)
+	if host == "" || isIP(host) {
+		return host
+	}
+	if domain := p.Domain(host); domain != "" {
+		return domain
+	}
+
+	// Public suffix has no registrable domain
+	if p.isSuffix(host) {
+		return host
+	}
+
+	// Unknown suffix, apply the default rule
+	labels := strings.Split(host, ".")
+	if len(labels) > 2 {
+		return strings.Join(labels[len(labels)-2:], ".")
+	}
+	return host
+}
@@ -0,0 +1,63 @@
+package domainutil
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExampleSameSite() {
+	fmt.Println(SameSite("https://keep.google.com", "https://mail.google.com/mail"))
+	fmt.Println(SameSite("http://keep.google.com", "https://mail.google.com/mail"))
+	// Output: true
+	// false
+}
+
+// TestSameRegistrableDomain tests SameRegistrableDomain() function
+func TestSameRegistrableDomain(t *testing.T) {
+	for _, testCase := range []struct {
+		A, B     string
+		Expected bool
+	}{
+		{"google.com", "google.com", true},
+		{"keep.google.com", "https://mail.google.com/", true},
+		{"http://keep.google.com", "https://google.com.", true},
+		{"keep.google.co.uk", "keep.google.com", false},
+		{"google.co.uk", "amazon.co.uk", false},
+		{"co.uk", "co.uk", false},
+		{"evil-google.com", "google.com", false},
+		{"192.168.0.1", "192.168.0.1", false},
+		{"192.168.0.1", "192.168.0.2", false},
+		{"intranet.corp", "intranet.corp", false},
+		{"", "", false},
+	} {
+		if result := SameRegistrableDomain(testCase.A, testCase.B); result != testCase.Expected {
+			t.Errorf("Urls (%q, %q) returned %v for SameRegistrableDomain(), but %v was expected", testCase.A, testCase.B, result, testCase.Expected)
+		}
+	}
+}
+
+// TestSameSite tests SameSite() function
+func TestSameSite(t *testing.T) {
+	for _, testCase := range []struct {
+		A, B     string
+		Expected bool
+	}{
+		{"https://keep.google.com", "https://mail.google.com", true},
+		{"https://keep.google.com:8443", "HTTPS://google.com/path", true},
+		{"http://keep.google.com", "https://keep.google.com", false},
+		{"https://google.co.uk", "https://amazon.co.uk", false},
+		{"https://co.uk", "https://co.uk", true},
+		{"https://co.uk", "https://google.co.uk", false},
+		{"https://192.168.0.1", "https://192.168.0.1:8080", true},
+		{"https://192.168.0.1", "https://192.168.0.2", false},
+		{"https://[::1]", "https://[::1]:8080", true},
+		{"https://a.intranet.corp", "https://b.intranet.corp", true},
+		{"https://a.intranet.corp", "https://a.extranet.corp", false},
+		{"https://localhost", "https://localhost:8080", true},
+		{"https://", "https://", false},
+	} {
+		if result := SameSite(testCase.A, testCase.B); result != testCase.Expected {
+			t.Errorf("Urls (%q, %q) returned %v for SameSite(), but %v was expected", testCase.A, testCase.B, result, testCase.Expected)
+		}
+	}
+}
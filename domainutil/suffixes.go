@@ -0,0 +1,121 @@
+package domainutil
+
+import (
+	"sort"
+	"strings"
+)
+
+// IsPublicSuffix reports whether suffix (e.g. co.uk or github.io) is a public suffix itself.
+// Hosts matched by a wildcard rule are public suffixes too, unless an exception rule exempts them.
+func IsPublicSuffix(suffix string) bool {
+	return std().IsPublicSuffix(suffix)
+}
+
+// IsTLD reports whether tld is a top-level domain listed in the ICANN section of the suffix list.
+func IsTLD(tld string) bool {
+	return std().IsTLD(tld)
+}
+
+// ChildSuffixes returns sorted public suffixes listed below parent, e.g. co.jp and kawasaki.jp for jp.
+// Wildcard rules are returned as they are listed (*.kawasaki.jp), exception rules are skipped.
+func ChildSuffixes(parent string) []string {
+	return std().ChildSuffixes(parent)
+}
+
+// EachRule calls fn for every rule of the suffix list in the order of the list until fn returns false.
+func EachRule(fn func(rule Rule) bool) {
+	std().EachRule(fn)
+}
+
+// IsPublicSuffix reports whether suffix is a public suffix itself, see the IsPublicSuffix function.
+func (p *Parser) IsPublicSuffix(suffix string) bool {
+	host := p.cleanSuffix(suffix)
+	return host != "" && p.isSuffix(host)
+}
+
+// IsTLD reports whether tld is a top-level domain listed in the ICANN section of the suffix list.
+func (p *Parser) IsTLD(tld string) bool {
+	tld = strings.ToLower(p.cleanSuffix(tld))
+	if tld == "" || strings.Contains(tld, ".") {
+		return false
+	}
+	child, found := p.options.list.root.children[tld]
+	return found && child.rule != nil && child.rule.Section == ICANNSection
+}
+
+// ChildSuffixes returns sorted public suffixes listed below parent, see the ChildSuffixes function.
+// Private suffixes are skipped if the Parser uses ICANN rules only.
+func (p *Parser) ChildSuffixes(parent string) []string {
+	parent = strings.ToLower(p.cleanSuffix(parent))
+	if parent == "" {
+		return nil
+	}
+
+	// Find node of the parent
+	current := p.options.list.root
+	labels := labelsOf(parent)
+	for i := len(labels) - 1; i >= 0; i-- {
+		next, found := current.children[labels[i]]
+		if !found {
+			return nil
+		}
+		current = next
+	}
+
+	var suffixes []string
+	current.each(func(rule *Rule) {
+		if rule.Kind != ExceptionRule && rule != current.rule && !(p.options.icannOnly && rule.Section == PrivateSection) {
+			suffixes = append(suffixes, rule.Text)
+		}
+	})
+	sort.Strings(suffixes)
+	return suffixes
+}
+
+// EachRule calls fn for every rule of the suffix list in the order of the list until fn returns false.
+// Private rules are skipped if the Parser uses ICANN rules only.
+func (p *Parser) EachRule(fn func(rule Rule) bool) {
+	p.options.list.EachRule(func(rule Rule) bool {
+		if p.options.icannOnly && rule.Section == PrivateSection {
+			return true
+		}
+		return fn(rule)
+	})
+}
+
+// EachRule calls fn for every rule of the list in the order of the list until fn returns false.
+func (l *SuffixList) EachRule(fn func(rule Rule) bool) {
+	var rules []*Rule
+	l.root.each(func(rule *Rule) {
+		rules = append(rules, rule)
+	})
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Line < rules[j].Line
+	})
+	for _, rule := range rules {
+		if !fn(*rule) {
+			return
+		}
+	}
+}
+
+// each calls fn for every rule of the node and its descendants.
+func (n *node) each(fn func(rule *Rule)) {
+	if n.rule != nil {
+		fn(n.rule)
+	}
+	for _, child := range n.children {
+		child.each(fn)
+	}
+}
+
+// isSuffix reports whether cleaned host is a public suffix itself.
+func (p *Parser) isSuffix(host string) bool {
+	labels := labelsOf(strings.ToLower(host))
+	return p.options.list.suffixLength(labels, p.options.icannOnly, p.options.defaultRule) == len(labels)
+}
+
+// cleanSuffix cleans suffix entered with or without the leading dot.
+func (p *Parser) cleanSuffix(suffix string) string {
+	return p.cleanHost(strings.TrimPrefix(strings.TrimSpace(suffix), "."))
+}
@@ -0,0 +1,126 @@
+package domainutil
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func ExampleChildSuffixes() {
+	fmt.Println(IsPublicSuffix("co.uk"), IsPublicSuffix("google.co.uk"))
+	fmt.Println(IsTLD("uk"), IsTLD("co.uk"))
+	fmt.Println(ChildSuffixes("kawasaki.jp"))
+	// Output: true false
+	// true false
+	// [*.kawasaki.jp]
+}
+
+// TestIsPublicSuffix tests IsPublicSuffix() function
+func TestIsPublicSuffix(t *testing.T) {
+	for suffix, expected := range map[string]bool{
+		"com":            true,
+		"co.uk":          true,
+		".co.uk":         true,
+		"CO.UK.":         true,
+		"github.io":      true,
+		"foo.ck":         true,
+		"www.ck":         false,
+		"google.com":     false,
+		"unknowntld":     false,
+		"xn--55qx5d.cn":  true,
+		"公司.cn":          true,
+		"":               false,
+		"keep.google.io": false,
+	} {
+		if result := IsPublicSuffix(suffix); result != expected {
+			t.Errorf("Suffix (%q) returned %v for IsPublicSuffix(), but %v was expected", suffix, result, expected)
+		}
+	}
+
+	if NewParser(WithICANNOnly(true)).IsPublicSuffix("github.io") {
+		t.Errorf("Suffix (%q) returned true for IsPublicSuffix() with ICANN rules only, but false was expected", "github.io")
+	}
+}
+
+// TestIsTLD tests IsTLD() function
+func TestIsTLD(t *testing.T) {
+	for tld, expected := range map[string]bool{
+		"com":        true,
+		"UK":         true,
+		"xn--p1ai":   true,
+		"рф":         true,
+		"co.uk":      false,
+		"unknowntld": false,
+		"":           false,
+	} {
+		if result := IsTLD(tld); result != expected {
+			t.Errorf("TLD (%q) returned %v for IsTLD(), but %v was expected", tld, result, expected)
+		}
+	}
+}
+
+// TestChildSuffixes tests ChildSuffixes() and EachRule() functions on a custom list
+func TestChildSuffixes(t *testing.T) {
+	list, err := ReadSuffixList(strings.NewReader(`// ===BEGIN ICANN DOMAINS===
+jp
+co.jp
+*.kawasaki.jp
+!city.kawasaki.jp
+// ===END ICANN DOMAINS===
+// ===BEGIN PRIVATE DOMAINS===
+blogspot.jp
+// ===END PRIVATE DOMAINS===
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parser := NewParser(WithSuffixList(list))
+	for _, test := range []struct {
+		parser   *Parser
+		parent   string
+		expected []string
+	}{
+		{parser, "jp", []string{"*.kawasaki.jp", "blogspot.jp", "co.jp"}},
+		{NewParser(WithSuffixList(list), WithICANNOnly(true)), "jp", []string{"*.kawasaki.jp", "co.jp"}},
+		{parser, "kawasaki.jp", []string{"*.kawasaki.jp"}},
+		{parser, "co.jp", nil},
+		{parser, "uk", nil},
+	} {
+		if result := test.parser.ChildSuffixes(test.parent); !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("Parent (%q) returned %q for ChildSuffixes(), but %q was expected", test.parent, result, test.expected)
+		}
+	}
+
+	var rules []string
+	parser.EachRule(func(rule Rule) bool {
+		rules = append(rules, fmt.Sprintf("%s %s %s", rule.Text, rule.Kind, rule.Section))
+		return true
+	})
+	expected := []string{"jp normal ICANN", "co.jp normal ICANN", "*.kawasaki.jp wildcard ICANN", "!city.kawasaki.jp exception ICANN", "blogspot.jp normal PRIVATE"}
+	if !reflect.DeepEqual(rules, expected) {
+		t.Errorf("EachRule() returned %q, but %q was expected", rules, expected)
+	}
+
+	count := 0
+	parser.EachRule(func(rule Rule) bool {
+		count++
+		return false
+	})
+	if count != 1 {
+		t.Errorf("EachRule() called the function %d times after it returned false, but once was expected", count)
+	}
+}
+
+// TestEachRuleBuiltin tests that the built-in list has rules in both sections
+func TestEachRuleBuiltin(t *testing.T) {
+	sections := map[Section]int{}
+	EachRule(func(rule Rule) bool {
+		sections[rule.Section]++
+		return true
+	})
+	if sections[ICANNSection] < 1000 || sections[PrivateSection] < 1000 {
+		t.Errorf("EachRule() returned %v rules per section, but thousands were expected in each", sections)
+	}
+}
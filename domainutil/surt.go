@@ -0,0 +1,141 @@
+package domainutil
+
+import (
+	"errors"
+	"strings"
+)
+
+// errInvalidSURT is returned by FromSURT when the key is not in SURT form
+var errInvalidSURT = errors.New("domainutil: invalid SURT")
+
+// ToSURT converts provided url to SURT form (Sort-friendly URI Reordering Transform) used by web archives,
+// e.g. https://www.example.com/path?query becomes com,example,www)/path?query.
+// Protocol, credentials, default port & fragment are dropped. If no host is found in provided url, this function returns empty string.
+func ToSURT(url string) string {
+	return std().ToSURT(url)
+}
+
+// FromSURT converts SURT back to url without protocol, e.g. com,example,www)/path?query becomes www.example.com/path?query.
+func FromSURT(surt string) (string, error) {
+	end := strings.Index(surt, ")")
+	if end == -1 {
+		return "", errInvalidSURT
+	}
+	hostport, path := strings.TrimSuffix(surt[:end], ","), surt[end+1:]
+	host, port := splitHostPort(hostport)
+	if host == "" || strings.Contains(host, ".") && !isIP(host) {
+		return "", errInvalidSURT
+	}
+
+	// IP addresses are not reversed
+	if !isIP(host) {
+		host = reverseLabels(strings.Replace(host, ",", ".", -1))
+	}
+	if port != "" {
+		host += ":" + port
+	}
+	if path == "/" {
+		path = ""
+	}
+	return host + path, nil
+}
+
+// ReverseDomain returns host of provided url in reverse-DNS notation, e.g. www.example.com becomes com.example.www.
+// IP addresses are not reversed. If no host is found in provided url, this function returns empty string.
+func ReverseDomain(url string) string {
+	return std().ReverseDomain(url)
+}
+
+// FromReverseDomain converts host in reverse-DNS notation back, e.g. com.example.www becomes www.example.com.
+func FromReverseDomain(reversed string) string {
+	if isIP(reversed) {
+		return reversed
+	}
+	return reverseLabels(reversed)
+}
+
+// CompareHosts compares hosts of provided urls by their registrable domains and then by their subdomains
+// from the closest label, so hosts of a domain are sorted together (e.g. under co.uk).
+// Hosts without domain are sorted after the others by their hosts. The result is -1, 0 or +1 like strings.Compare.
+func CompareHosts(a, b string) int {
+	return std().CompareHosts(a, b)
+}
+
+// ToSURT converts provided url to SURT form, see the ToSURT function.
+func (p *Parser) ToSURT(url string) string {
+	rest := url
+	if index := strings.Index(rest, "://"); index > -1 {
+		rest = rest[index+3:]
+	}
+	path := ""
+	if index := strings.IndexAny(rest, "/?#"); index > -1 {
+		path = rest[index:]
+	}
+	if index := strings.Index(path, "#"); index > -1 {
+		path = path[:index]
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	host := p.ReverseDomain(url)
+	if host == "" {
+		return ""
+	}
+	if !isIP(host) {
+		host = strings.Replace(host, ".", ",", -1)
+	}
+	if _, port := splitHostPort(authority(url)); port != "" && port != defaultPorts[strings.ToLower(Protocol(url))] {
+		host += ":" + port
+	}
+	return host + ")" + path
+}
+
+// ReverseDomain returns host of provided url in reverse-DNS notation, see the ReverseDomain function.
+func (p *Parser) ReverseDomain(url string) string {
+	host := p.split(url).host
+	if host == "" || isIP(host) {
+		return host
+	}
+	return reverseLabels(host)
+}
+
+// CompareHosts compares hosts of provided urls by their registrable domains and subdomains, see the CompareHosts function.
+func (p *Parser) CompareHosts(a, b string) int {
+	partsA, partsB := p.split(a), p.split(b)
+	domainA, domainB := partsA.domain(), partsB.domain()
+	switch {
+	case domainA == "" && domainB == "":
+		return strings.Compare(partsA.host, partsB.host)
+	case domainA == "":
+		return 1
+	case domainB == "":
+		return -1
+	}
+	if result := strings.Compare(domainA, domainB); result != 0 {
+		return result
+	}
+
+	// Compare subdomains label by label from the right, so parents sort right before their children
+	labelsA, labelsB := subdomainLabels(partsA.subdomain), subdomainLabels(partsB.subdomain)
+	for i := 0; i < len(labelsA) && i < len(labelsB); i++ {
+		if result := strings.Compare(labelsA[len(labelsA)-1-i], labelsB[len(labelsB)-1-i]); result != 0 {
+			return result
+		}
+	}
+	switch {
+	case len(labelsA) < len(labelsB):
+		return -1
+	case len(labelsA) > len(labelsB):
+		return 1
+	}
+	return 0
+}
+
+// subdomainLabels splits subdomain into labels, empty subdomain has none.
+func subdomainLabels(subdomain string) []string {
+	if subdomain == "" {
+		return nil
+	}
+	return labelsOf(subdomain)
+}
@@ -0,0 +1,210 @@
+package domainutil
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// TLDType is a type of a top-level domain as listed in the IANA root zone database
+type TLDType int
+
+const (
+	// UnknownTLD is a TLD missing from the root zone database (or any non-ccTLD if no database is loaded)
+	UnknownTLD TLDType = iota
+	// GenericTLD is a generic TLD such as com or app
+	GenericTLD
+	// CountryCodeTLD is a country-code TLD such as uk or рф
+	CountryCodeTLD
+	// SponsoredTLD is a sponsored TLD such as gov or museum
+	SponsoredTLD
+	// GenericRestrictedTLD is a generic-restricted TLD such as biz or name
+	GenericRestrictedTLD
+	// InfrastructureTLD is the infrastructure TLD arpa
+	InfrastructureTLD
+	// TestTLD is a TLD reserved for testing such as テスト
+	TestTLD
+)
+
+// tldTypeNames holds names of the types as used by the root zone database
+var tldTypeNames = map[TLDType]string{
+	UnknownTLD:           "unknown",
+	GenericTLD:           "generic",
+	CountryCodeTLD:       "country-code",
+	SponsoredTLD:         "sponsored",
+	GenericRestrictedTLD: "generic-restricted",
+	InfrastructureTLD:    "infrastructure",
+	TestTLD:              "test",
+}
+
+// String returns name of the type as used by the root zone database.
+func (t TLDType) String() string {
+	if name, found := tldTypeNames[t]; found {
+		return name
+	}
+	return "unknown"
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t TLDType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// ccTLDCountries holds ISO 3166-1 codes of ccTLDs not matching their country code
+var ccTLDCountries = map[string]string{
+	"uk": "GB", // United Kingdom
+	"ac": "SH", // Ascension Island
+	"eu": "",   // European Union
+	"su": "",   // Soviet Union
+}
+
+// idnCcTLDs maps IDN ccTLDs to their ASCII ccTLD equivalents
+var idnCcTLDs = map[string]string{
+	"ישראל": "il", "امارات": "ae", "հայ": "am", "বাংলা": "bd", "бг": "bg", "البحرين": "bh", "бел": "by",
+	"中国": "cn", "中國": "cn", "الجزائر": "dz", "مصر": "eg", "ею": "eu", "ευ": "eu", "გე": "ge", "ελ": "gr",
+	"香港": "hk", "ಭಾರತ": "in", "ଭାରତ": "in", "ভাৰত": "in", "भारतम्": "in", "भारोत": "in", "ڀارت": "in",
+	"ഭാരതം": "in", "भारत": "in", "بارت": "in", "بھارت": "in", "భారత్": "in", "ભારત": "in", "ਭਾਰਤ": "in",
+	"ভারত": "in", "இந்தியா": "in", "عراق": "iq", "ایران": "ir", "ايران": "ir", "الاردن": "jo", "한국": "kr",
+	"қаз": "kz", "ລາວ": "la", "ලංකා": "lk", "இலங்கை": "lk", "المغرب": "ma", "мкд": "mk", "мон": "mn",
+	"澳門": "mo", "澳门": "mo", "موريتانيا": "mr", "مليسيا": "my", "عمان": "om", "پاکستان": "pk", "پاكستان": "pk",
+	"فلسطين": "ps", "قطر": "qa", "срб": "rs", "рф": "ru", "السعودية": "sa", "السعودیة": "sa", "السعودیۃ": "sa",
+	"السعوديه": "sa", "سودان": "sd", "新加坡": "sg", "சிங்கப்பூர்": "sg", "سورية": "sy", "سوريا": "sy",
+	"ไทย": "th", "تونس": "tn", "台灣": "tw", "台湾": "tw", "臺灣": "tw", "укр": "ua", "اليمن": "ye",
+}
+
+// RootZone is a parsed export of the IANA root zone database (https://www.iana.org/domains/root/db).
+type RootZone struct {
+	tlds map[string]rootZoneEntry
+}
+
+// rootZoneEntry is a single TLD of the root zone database
+type rootZoneEntry struct {
+	kind    TLDType
+	manager string
+}
+
+// TLDDetails describes a top-level domain.
+type TLDDetails struct {
+	TLD             string  // TLD in unicode, e.g. uk or рф
+	Type            TLDType // Type of the TLD
+	Manager         string  // Sponsoring organization from the root zone database, empty if not known
+	Country         string  // ISO 3166-1 alpha-2 code of a ccTLD (GB for uk), empty if not known or not a ccTLD
+	ASCIIEquivalent string  // ASCII ccTLD equivalent of an IDN ccTLD (ru for рф), empty otherwise
+}
+
+// ReadRootZone reads the root zone database exported as CSV with Domain, Type and TLD Manager columns.
+// The header line is optional, domains may be listed with or without the leading dot.
+func ReadRootZone(r io.Reader) (*RootZone, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	zone := &RootZone{tlds: map[string]rootZoneEntry{}}
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) < 2 {
+			return nil, fmt.Errorf("domainutil: root zone line %d: expected domain and type", line)
+		}
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "domain") {
+			continue // Header
+		}
+
+		// The database marks direction of right-to-left domains
+		tld := strings.ToLower(strings.Trim(record[0], " .\u200e\u200f"))
+		if strings.HasPrefix(tld, "xn--") {
+			if tld, err = idna.ToUnicode(tld); err != nil {
+				return nil, fmt.Errorf("domainutil: root zone line %d: %v", line, err)
+			}
+		}
+		kind, found := parseTLDType(record[1])
+		if tld == "" || !found {
+			return nil, fmt.Errorf("domainutil: root zone line %d: invalid record %q", line, strings.Join(record, ","))
+		}
+
+		entry := rootZoneEntry{kind: kind}
+		if len(record) > 2 {
+			entry.manager = strings.TrimSpace(record[2])
+		}
+		zone.tlds[tld] = entry
+	}
+	if len(zone.tlds) == 0 {
+		return nil, errors.New("domainutil: root zone has no TLDs")
+	}
+	return zone, nil
+}
+
+// parseTLDType returns type of its name in the root zone database.
+func parseTLDType(name string) (TLDType, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for kind, typeName := range tldTypeNames {
+		if typeName == name && kind != UnknownTLD {
+			return kind, true
+		}
+	}
+	return UnknownTLD, false
+}
+
+// SetRootZone sets the root zone database used by TLDInfo, nil unsets it.
+func SetRootZone(zone *RootZone) {
+	updateDefaultParser(WithRootZone(zone))
+}
+
+// WithRootZone makes the Parser classify TLDs by provided root zone database.
+func WithRootZone(zone *RootZone) Option {
+	return func(p *Parser) { p.rootZone = zone }
+}
+
+// TLDInfo describes top-level domain of provided url.
+// Without root zone database set by SetRootZone, only ccTLDs are recognized (two letter and known IDN ccTLDs).
+// If the url has no known suffix, found is false.
+func TLDInfo(url string) (info TLDDetails, found bool) {
+	return std().TLDInfo(url)
+}
+
+// TLDInfo describes top-level domain of provided url, see the TLDInfo function.
+func (p *Parser) TLDInfo(url string) (info TLDDetails, found bool) {
+	suffix := p.DomainSuffix(url)
+	if suffix == "" {
+		return TLDDetails{}, false
+	}
+	tld := strings.ToLower(suffix[strings.LastIndex(suffix, ".")+1:])
+	if strings.HasPrefix(tld, "xn--") {
+		if unicode, err := idna.ToUnicode(tld); err == nil {
+			tld = unicode
+		}
+	}
+
+	// Classify by the database or recognize ccTLDs
+	info = TLDDetails{TLD: tld, ASCIIEquivalent: idnCcTLDs[tld]}
+	if p.rootZone != nil {
+		entry := p.rootZone.tlds[tld]
+		info.Type, info.Manager = entry.kind, entry.manager
+	} else if info.ASCIIEquivalent != "" || len(tld) == 2 && isASCII(tld) {
+		info.Type = CountryCodeTLD
+	}
+
+	// Country code matches the ccTLD with a few exceptions
+	if info.Type == CountryCodeTLD {
+		ascii := tld
+		if info.ASCIIEquivalent != "" {
+			ascii = info.ASCIIEquivalent
+		}
+		if country, found := ccTLDCountries[ascii]; found {
+			info.Country = country
+		} else if len(ascii) == 2 {
+			info.Country = strings.ToUpper(ascii)
+		}
+	}
+	if p.options.ascii {
+		info.TLD = toASCII(info.TLD)
+	}
+	return info, true
+}
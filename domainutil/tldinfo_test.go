@@ -0,0 +1,98 @@
+package domainutil
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// rootZoneExport is an excerpt of the IANA root zone database export
+const rootZoneExport = `Domain,Type,TLD Manager
+.ac,country-code,Internet Computer Bureau Limited
+.arpa,infrastructure,Internet Architecture Board (IAB)
+.biz,generic-restricted,"Registry Services, LLC"
+.com,generic,VeriSign Global Registry Services
+.gov,sponsored,Cybersecurity and Infrastructure Security Agency
+.uk,country-code,Nominet UK
+.xn--p1ai,country-code,Coordination Center for TLD RU
+.テスト,test,Internet Assigned Numbers Authority
+` +
+	".\u200fקום\u200e,generic,Internet Naming Company LLC\n" // Right-to-left domains are marked
+
+func ExampleTLDInfo() {
+	zone, _ := ReadRootZone(strings.NewReader(rootZoneExport))
+	SetRootZone(zone)
+	defer SetRootZone(nil)
+
+	info, _ := TLDInfo("https://www.bbc.co.uk/news")
+	fmt.Println(info.TLD, info.Type, info.Country, info.Manager)
+	// Output: uk country-code GB Nominet UK
+}
+
+// TestTLDInfo tests TLDInfo() function with a root zone database
+func TestTLDInfo(t *testing.T) {
+	zone, err := ReadRootZone(strings.NewReader(rootZoneExport))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parser := NewParser(WithRootZone(zone))
+	for url, expected := range map[string]TLDDetails{
+		"keep.google.com":  {TLD: "com", Type: GenericTLD, Manager: "VeriSign Global Registry Services"},
+		"www.bbc.co.uk":    {TLD: "uk", Type: CountryCodeTLD, Manager: "Nominet UK", Country: "GB"},
+		"президент.рф":     {TLD: "рф", Type: CountryCodeTLD, Manager: "Coordination Center for TLD RU", Country: "RU", ASCIIEquivalent: "ru"},
+		"example.xn--p1ai": {TLD: "рф", Type: CountryCodeTLD, Manager: "Coordination Center for TLD RU", Country: "RU", ASCIIEquivalent: "ru"},
+		"nic.ac":           {TLD: "ac", Type: CountryCodeTLD, Manager: "Internet Computer Bureau Limited", Country: "SH"},
+		"example.biz":      {TLD: "biz", Type: GenericRestrictedTLD, Manager: "Registry Services, LLC"},
+		"whitehouse.gov":   {TLD: "gov", Type: SponsoredTLD, Manager: "Cybersecurity and Infrastructure Security Agency"},
+		"example.arpa":     {TLD: "arpa", Type: InfrastructureTLD, Manager: "Internet Architecture Board (IAB)"},
+		"example.קום":      {TLD: "קום", Type: GenericTLD, Manager: "Internet Naming Company LLC"},
+		"example.de":       {TLD: "de", Type: UnknownTLD},
+		"bobesa.github.io": {TLD: "io", Type: UnknownTLD},
+	} {
+		info, found := parser.TLDInfo(url)
+		if !found || info != expected {
+			t.Errorf("Url (%q) returned %+v, %v for TLDInfo(), but %+v was expected", url, info, found, expected)
+		}
+	}
+
+	if info, found := parser.TLDInfo("example.unknowntld"); found {
+		t.Errorf("Url (%q) returned %+v for TLDInfo(), but nothing was expected", "example.unknowntld", info)
+	}
+}
+
+// TestTLDInfoWithoutRootZone tests that ccTLDs are recognized without root zone database
+func TestTLDInfoWithoutRootZone(t *testing.T) {
+	for url, expected := range map[string]TLDDetails{
+		"keep.google.com":  {TLD: "com", Type: UnknownTLD},
+		"www.bbc.co.uk":    {TLD: "uk", Type: CountryCodeTLD, Country: "GB"},
+		"example.de":       {TLD: "de", Type: CountryCodeTLD, Country: "DE"},
+		"europa.eu":        {TLD: "eu", Type: CountryCodeTLD},
+		"example.中国":       {TLD: "中国", Type: CountryCodeTLD, Country: "CN", ASCIIEquivalent: "cn"},
+		"example.xn--p1ai": {TLD: "рф", Type: CountryCodeTLD, Country: "RU", ASCIIEquivalent: "ru"},
+	} {
+		info, found := TLDInfo(url)
+		if !found || info != expected {
+			t.Errorf("Url (%q) returned %+v, %v for TLDInfo(), but %+v was expected", url, info, found, expected)
+		}
+	}
+
+	if info, _ := NewParser(WithASCII(true)).TLDInfo("example.рф"); info.TLD != "xn--p1ai" {
+		t.Errorf("Url (%q) returned %q TLD for TLDInfo() with ASCII output, but %q was expected", "example.рф", info.TLD, "xn--p1ai")
+	}
+}
+
+// TestReadRootZoneErrors tests that malformed root zone databases are rejected
+func TestReadRootZoneErrors(t *testing.T) {
+	for _, data := range []string{
+		"",
+		"Domain,Type,TLD Manager\n",
+		".com\n",
+		".com,commercial,VeriSign\n",
+		",generic,Nobody\n",
+	} {
+		if _, err := ReadRootZone(strings.NewReader(data)); err == nil {
+			t.Errorf("Root zone (%q) returned no error for ReadRootZone(), but error was expected", data)
+		}
+	}
+}
@@ -1,144 +1,114 @@
 package domainutil
 
 import (
+	"errors"
 	"strings"
-
-	"golang.org/x/net/idna"
 )
 
 // HasSubdomain reports whether domain contains any subdomain.
 func HasSubdomain(domain string) bool {
-	domain, top := stripURLParts(domain), Domain(domain)
-	return domain != top && top != ""
+	return std().HasSubdomain(domain)
 }
 
 // Subdomain returns subdomain from provided url.
 // If subdomain is not found in provided url, this function returns empty string.
 func Subdomain(url string) string {
-	domain, top := stripURLParts(url), Domain(url)
-	lt, ld := len(top), len(domain)
-	if lt < ld && top != "" {
-		return domain[:(ld-lt)-1]
-	}
-	return ""
+	return std().Subdomain(url)
 }
 
 // SplitDomain split domain into string array
 // for example, zh.wikipedia.org will split into {"zh", "wikipedia", "org"}
 func SplitDomain(url string) []string {
-	domain, second, top := Subdomain(url), DomainPrefix(url), DomainSuffix(url)
-	if len(top) == 0 {
-		return nil
-	}
-
-	if len(second) == 0 {
-		return []string{top}
-	}
-
-	if len(domain) == 0 {
-		return []string{second, top}
-	}
-
-	array := strings.Split(domain, ".")
-	res := append(array, second, top)
-	return res
+	return std().SplitDomain(url)
 }
 
 // DomainPrefix returns second-level domain from provided url.
 // If no SLD is found in provided url, this function returns empty string.
 func DomainPrefix(url string) string {
-	domain := Domain(url)
-	if len(domain) != 0 {
-		return domain[:strings.Index(domain, ".")]
-	}
-	return ""
+	return std().DomainPrefix(url)
 }
 
 // DomainSuffix returns domain suffix from provided url.
 // If no TLD is found in provided url, this function returns empty string.
 func DomainSuffix(url string) string {
-	domain := Domain(url)
-	if len(domain) != 0 {
-		return domain[strings.Index(domain, ".")+1:]
-	}
-	return ""
+	return std().DomainSuffix(url)
 }
 
 // Domain returns top level domain from url string.
 // If no domain is found in provided url, this function returns empty string.
 // If no TLD is found in provided url, this function returns empty string.
 func Domain(url string) string {
-	domain, top := stripURLParts(url), ""
-	parts := strings.Split(domain, ".")
-	currentTld := *tlds
-	foundTld := false
-
-	// Cycle trough parts in reverse
-	if len(parts) > 1 {
-		for i := len(parts) - 1; i >= 0; i-- {
-			// Generate top domain output
-			if top != "" {
-				top = "." + top
-			}
-			top = parts[i] + top
-
-			// Check for TLD
-			if currentTld == nil {
-				return top // Return current output because we no longer have the TLD
-			} else if tldEntry, found := currentTld[parts[i]]; found {
-				if tldEntry != nil {
-					currentTld = *tldEntry
-				} else {
-					currentTld = nil
-				}
-				foundTld = true
-				continue
-			} else if foundTld {
-				return top // Return current output if tld was found before
-			}
-
-			// Return empty string if no tld was found ever
-			return ""
-		}
-	}
+	return std().Domain(url)
+}
 
-	return ""
+// Parts holds all parts of an url extracted by this package.
+type Parts struct {
+	Protocol  string // Protocol as returned by Protocol
+	Username  string // Username as returned by Username
+	Password  string // Password as returned by Password
+	Host      string // Normalized host
+	Subdomain string // Subdomain as returned by Subdomain
+	SLD       string // Second-level domain as returned by DomainPrefix
+	Suffix    string // Public suffix as returned by DomainSuffix
+	Domain    string // Registrable domain as returned by Domain
 }
 
-// stripURLParts removes path, protocol & query from url and returns it.
+var (
+	// ErrNoHost is returned by Parse when no host is found in the url
+	ErrNoHost = errors.New("domainutil: no host found")
+	// ErrNoDomain is returned by Parse when the host has no registrable domain (e.g. its TLD is unknown)
+	ErrNoDomain = errors.New("domainutil: no registrable domain found")
+)
+
+// Parse splits provided url into all its parts in one pass.
+// If the url has no host or the host has no registrable domain, the parts found so far are returned with an error.
+func Parse(url string) (Parts, error) {
+	return std().Parse(url)
+}
+
+// stripURLParts removes path, protocol, credentials, port & query from url and returns its cleaned host.
 func stripURLParts(url string) string {
-	// Lower case the url
-	url = strings.ToLower(url)
+	return std().stripURLParts(url)
+}
 
+// authority returns the part of url between protocol and path (credentials, host & port).
+func authority(url string) string {
 	// Strip protocol
 	if index := strings.Index(url, "://"); index > -1 {
 		url = url[index+3:]
 	}
 
-	// Now, if the url looks like this: username:password@www.example.com/path?query=?
-	// we remove the content before the '@' symbol
-	if index := strings.Index(url, "@"); index > -1 {
-		url = url[index+1:]
+	// Strip path, query & fragment
+	if index := strings.IndexAny(url, "/?#"); index > -1 {
+		url = url[:index]
 	}
 
-	// Strip path (and query with it)
-	if index := strings.Index(url, "/"); index > -1 {
-		url = url[:index]
-	} else if index := strings.Index(url, "?"); index > -1 { // Strip query if path is not found
-		url = url[:index]
+	// Now, if the url looks like this: username:password@www.example.com
+	// we remove the content before the '@' symbol
+	if index := strings.LastIndex(url, "@"); index > -1 {
+		url = url[index+1:]
 	}
+	return url
+}
 
-	// Convert domain to unicode
-	if strings.Index(url, "xn--") != -1 {
-		var err error
-		url, err = idna.ToUnicode(url)
-		if err != nil {
-			return ""
+// splitHostPort splits hostport into host and port.
+// IPv6 literals keep their square brackets. If no port is present, port is empty.
+func splitHostPort(hostport string) (host, port string) {
+	if strings.HasPrefix(hostport, "[") {
+		end := strings.Index(hostport, "]")
+		if end == -1 {
+			return hostport, ""
 		}
+		host, hostport = hostport[:end+1], hostport[end+1:]
+		if strings.HasPrefix(hostport, ":") {
+			port = hostport[1:]
+		}
+		return host, port
 	}
-
-	// Return domain
-	return url
+	if index := strings.LastIndex(hostport, ":"); index > -1 {
+		return hostport[:index], hostport[index+1:]
+	}
+	return hostport, ""
 }
 
 // Protocol returns protocol from given url
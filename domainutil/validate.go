@@ -0,0 +1,160 @@
+package domainutil
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/idna"
+	"golang.org/x/text/secure/bidirule"
+	"golang.org/x/text/unicode/bidi"
+)
+
+const (
+	// maxLabelLength is the maximum length of a single label in octets (RFC 1035)
+	maxLabelLength = 63
+	// maxHostLength is the maximum length of a host in octets, without the trailing dot (RFC 1035)
+	maxHostLength = 253
+)
+
+// SetStrict switches strict mode on or off.
+// In strict mode every function of this package treats hosts that fail Validate as not found.
+func SetStrict(enabled bool) {
+	updateDefaultParser(WithStrict(enabled))
+}
+
+// HostnameError describes why a host failed validation.
+type HostnameError struct {
+	Host     string // Host as passed to Validate
+	Label    string // Offending label, empty if the host as a whole is invalid
+	Position int    // Zero based index of the offending label from the left, -1 if the host as a whole is invalid
+	Reason   string // Reason of the failure
+}
+
+// Error implements the error interface.
+func (e *HostnameError) Error() string {
+	if e.Position < 0 {
+		return fmt.Sprintf("domainutil: invalid host %q: %s", e.Host, e.Reason)
+	}
+	return fmt.Sprintf("domainutil: invalid label %q at position %d of host %q: %s", e.Label, e.Position, e.Host, e.Reason)
+}
+
+// IsValidHostname reports whether host is a syntactically valid hostname.
+func IsValidHostname(host string) bool {
+	return std().IsValidHostname(host)
+}
+
+// IsValidHostname reports whether host is a syntactically valid hostname, see the IsValidHostname function.
+func (p *Parser) IsValidHostname(host string) bool {
+	return p.Validate(host) == nil
+}
+
+// Validate checks whether host is a syntactically valid hostname.
+//
+// ASCII labels must follow the LDH rule of RFC 1035 and RFC 1123: letters, digits and hyphens only,
+// no leading or trailing hyphen and at most 63 octets. Labels containing other characters
+// and A-labels (xn--) are validated as IDNA labels per RFC 5891. Once any label is right-to-left,
+// every label including ASCII ones must follow the Bidi rule of RFC 5893.
+// The whole host may have at most 253 octets in its ASCII form. A single trailing dot is allowed.
+//
+// If host is invalid, the returned error is a *HostnameError.
+func Validate(host string) error {
+	return std().Validate(host)
+}
+
+// Validate checks whether host is a syntactically valid hostname, see the Validate function.
+// The result does not depend on the options of the Parser.
+func (p *Parser) Validate(host string) error {
+	name := strings.TrimSuffix(host, ".")
+	if name == "" {
+		return &HostnameError{Host: host, Position: -1, Reason: "empty host"}
+	}
+
+	labels := strings.Split(name, ".")
+	length := len(labels) - 1 // Dots between labels
+	for i, label := range labels {
+		ascii, reason := validateLabel(label)
+		if reason != "" {
+			return &HostnameError{Host: host, Label: label, Position: i, Reason: reason}
+		}
+		length += len(ascii)
+	}
+
+	// Bidi rule applies to every label once any label is right-to-left
+	if i := bidiViolation(labels); i >= 0 {
+		return &HostnameError{Host: host, Label: labels[i], Position: i, Reason: "label breaks the Bidi rule of a right-to-left host"}
+	}
+
+	if length > maxHostLength {
+		return &HostnameError{Host: host, Position: -1, Reason: fmt.Sprintf("host is %d octets long, at most %d are allowed", length, maxHostLength)}
+	}
+	return nil
+}
+
+// validateLabel validates single label and returns its ASCII form.
+// If the label is invalid, the reason is returned instead.
+func validateLabel(label string) (ascii, reason string) {
+	if label == "" {
+		return "", "empty label"
+	}
+
+	// Internationalized labels are validated by IDNA rules
+	if !isASCII(label) || strings.HasPrefix(strings.ToLower(label), "xn--") {
+		var err error
+		ascii, err = idna.Registration.ToASCII(strings.ToLower(label))
+		if err != nil {
+			return "", "invalid internationalized label (" + err.Error() + ")"
+		}
+		label = ascii
+	}
+
+	if len(label) > maxLabelLength {
+		return "", fmt.Sprintf("label is %d octets long, at most %d are allowed", len(label), maxLabelLength)
+	}
+	for i := 0; i < len(label); i++ {
+		if c := label[i]; !isLDH(c) {
+			return "", fmt.Sprintf("invalid character %q", c)
+		}
+	}
+	if label[0] == '-' {
+		return "", "label starts with a hyphen"
+	}
+	if label[len(label)-1] == '-' {
+		return "", "label ends with a hyphen"
+	}
+	return label, ""
+}
+
+// bidiViolation returns index of the first label breaking the Bidi rule of RFC 5893.
+// The rule is only checked if any label is right-to-left, otherwise -1 is returned.
+func bidiViolation(labels []string) int {
+	unicode, rtl := make([]string, len(labels)), false
+	for i, label := range labels {
+		unicode[i], _ = idna.ToUnicode(strings.ToLower(label))
+		rtl = rtl || bidirule.DirectionString(unicode[i]) == bidi.RightToLeft
+	}
+	if !rtl {
+		return -1
+	}
+
+	for i, label := range unicode {
+		if !bidirule.ValidString(label) {
+			return i
+		}
+	}
+	return -1
+}
+
+// isLDH reports whether c is a letter, digit or hyphen.
+func isLDH(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-'
+}
+
+// isASCII reports whether s contains only ASCII characters.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,101 @@
+package domainutil
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func ExampleValidate() {
+	fmt.Println(Validate("keep.google.com"))
+	fmt.Println(Validate("keep.-google.com"))
+	// Output: <nil>
+	// domainutil: invalid label "-google" at position 1 of host "keep.-google.com": label starts with a hyphen
+}
+
+// TestValidate tests Validate() function
+func TestValidate(t *testing.T) {
+	for _, testCase := range []struct {
+		Host     string
+		Valid    bool
+		Label    string
+		Position int
+	}{
+		{"google.com", true, "", 0},
+		{"google.com.", true, "", 0},
+		{"GAMA.Google.co.uk", true, "", 0},
+		{"1password.com", true, "", 0},
+		{"r3---sn-abc.googlevideo.com", true, "", 0},
+		{"xn--n3h.example", true, "", 0},
+		{"☃.example", true, "", 0},
+		{"müller.de", true, "", 0},
+		{"a.אב", true, "", 0},
+		{"אב.a", true, "", 0},
+		{"0a.example", true, "", 0},
+		{"0a.אב", false, "0a", 0},
+		{"אב.0a", false, "0a", 1},
+		{"xn--4db.0a", false, "0a", 1},
+		{"", false, "", -1},
+		{".", false, "", -1},
+		{"nonexist.***", false, "***", 1},
+		{"google..com", false, "", 1},
+		{".google.com", false, "", 0},
+		{"google.com..", false, "", 2},
+		{"-google.com", false, "-google", 0},
+		{"google-.com", false, "google-", 0},
+		{"goo_gle.com", false, "goo_gle", 0},
+		{"goo gle.com", false, "goo gle", 0},
+		{"xn--äää.com", false, "xn--äää", 0},
+		{"xn--zz.com", false, "xn--zz", 0},
+		{strings.Repeat("a", 63) + ".com", true, "", 0},
+		{strings.Repeat("a", 64) + ".com", false, strings.Repeat("a", 64), 0},
+		{strings.Repeat("a.", 125) + "com", true, "", 0},
+		{strings.Repeat("a.", 126) + "com", false, "", -1},
+	} {
+		err := Validate(testCase.Host)
+		if testCase.Valid {
+			if err != nil {
+				t.Errorf("Host (%q) returned %v for Validate(), but nil was expected", testCase.Host, err)
+			}
+			continue
+		}
+
+		hostErr, ok := err.(*HostnameError)
+		if !ok {
+			t.Errorf("Host (%q) returned %v for Validate(), but *HostnameError was expected", testCase.Host, err)
+			continue
+		}
+		if hostErr.Label != testCase.Label || hostErr.Position != testCase.Position {
+			t.Errorf("Host (%q) returned label %q at %d for Validate(), but %q at %d was expected", testCase.Host, hostErr.Label, hostErr.Position, testCase.Label, testCase.Position)
+		}
+	}
+}
+
+// BenchmarkValidate benchmarks Validate() function
+func BenchmarkValidate(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Validate("beta.gama.google.co.uk")
+	}
+}
+
+// TestSetStrict tests that strict mode rejects invalid hosts in package functions
+func TestSetStrict(t *testing.T) {
+	defer SetStrict(false)
+
+	for _, testCase := range []struct {
+		URL, Lax, Strict string
+	}{
+		{"http://goo_gle.com/path", "goo_gle.com", ""},
+		{"http://-google.com", "-google.com", ""},
+		{"http://gama.google.com", "google.com", "google.com"},
+	} {
+		SetStrict(false)
+		if result := Domain(testCase.URL); result != testCase.Lax {
+			t.Errorf("Url (%q) returned %q for Domain(), but %q was expected", testCase.URL, result, testCase.Lax)
+		}
+		SetStrict(true)
+		if result := Domain(testCase.URL); result != testCase.Strict {
+			t.Errorf("Url (%q) returned %q for Domain() in strict mode, but %q was expected", testCase.URL, result, testCase.Strict)
+		}
+	}
+}